@@ -0,0 +1,107 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/plimble/ace"
+)
+
+func init() {
+	register(aceAdapter{})
+}
+
+type aceAdapter struct{}
+
+func (aceAdapter) Name() string { return "Ace" }
+func (aceAdapter) Features() Feature {
+	return FeatureParams | FeatureMethodNotAllowed | FeatureWildcard | FeatureMiddleware | FeatureAdd
+}
+
+func (aceAdapter) Unsupported(f Feature) string {
+	switch f {
+	case FeatureMixedSegments:
+		return "panics when a static segment and a parameter share a position"
+	}
+	return ""
+}
+
+func (aceAdapter) Load(routes []route) http.Handler {
+	return loadAce(routes)
+}
+
+func (aceAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := aceHandle
+	if write {
+		h = aceHandleWrite
+	}
+	return loadAceSingle(method, path, h)
+}
+
+func (aceAdapter) Add(router http.Handler, r route) {
+	h := aceHandle
+	if loadTestHandler {
+		h = aceHandleTest
+	}
+	router.(*ace.Ace).Handle(r.method, r.path, []ace.HandlerFunc{h})
+}
+
+func aceHandle(_ *ace.C) {}
+
+func aceHandleWrite(c *ace.C) {
+	io.WriteString(c.Writer, c.Param("name"))
+}
+
+func aceHandleTest(c *ace.C) {
+	io.WriteString(c.Writer, c.Request.RequestURI)
+}
+
+func aceHandleParams(path string) ace.HandlerFunc {
+	return func(c *ace.C) {
+		writeParams(c.Writer, path, func(name string, _ bool) string {
+			return c.Param(name)
+		})
+	}
+}
+
+func aceMiddleware(c *ace.C) {
+	c.Next()
+}
+
+func aceMiddlewareTest(c *ace.C) {
+	middlewareCalls.Add(1)
+	c.Next()
+}
+
+func loadAce(routes []route) http.Handler {
+	h := []ace.HandlerFunc{aceHandle}
+	if loadTestHandler {
+		h = []ace.HandlerFunc{aceHandleTest}
+	}
+
+	router := ace.New()
+	mw := aceMiddleware
+	if loadTestHandler {
+		mw = aceMiddlewareTest
+	}
+	for range loadMiddlewares {
+		router.Use(mw)
+	}
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = []ace.HandlerFunc{aceHandleParams(route.path)}
+		}
+		router.Handle(route.method, route.path, h)
+	}
+	return router
+}
+
+func loadAceSingle(method, path string, handle ace.HandlerFunc) http.Handler {
+	router := ace.New()
+	router.Handle(method, path, []ace.HandlerFunc{handle})
+	return router
+}
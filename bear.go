@@ -0,0 +1,111 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/ursiform/bear"
+)
+
+func init() {
+	register(bearAdapter{})
+}
+
+type bearAdapter struct{}
+
+func (bearAdapter) Name() string { return "Bear" }
+func (bearAdapter) Features() Feature {
+	return FeatureParams | FeatureWildcard | FeatureMixedSegments | FeatureAdd
+}
+
+// Methods leaves out PATCH, which Bear doesn't know.
+func (bearAdapter) Methods() []string {
+	return []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete}
+}
+
+func (bearAdapter) Load(routes []route) http.Handler {
+	return loadBear(routes)
+}
+
+func (bearAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := bearHandler
+	if write {
+		h = bearHandlerWrite
+		if wildcardRe.MatchString(path) {
+			h = bearHandlerWriteWildcard
+		}
+	}
+	return loadBearSingle(method, bearPath(path), h)
+}
+
+func (bearAdapter) Add(router http.Handler, r route) {
+	h := bearHandler
+	if loadTestHandler {
+		h = bearHandlerTest
+	}
+	bearOn(router.(*bear.Mux), r.method, bearPath(r.path), h)
+}
+
+func bearHandler(_ http.ResponseWriter, _ *http.Request, _ *bear.Context) {}
+
+func bearHandlerWrite(w http.ResponseWriter, _ *http.Request, ctx *bear.Context) {
+	io.WriteString(w, ctx.Params["name"])
+}
+
+func bearHandlerWriteWildcard(w http.ResponseWriter, _ *http.Request, ctx *bear.Context) {
+	io.WriteString(w, ctx.Params["*"])
+}
+
+func bearHandlerTest(w http.ResponseWriter, r *http.Request, _ *bear.Context) {
+	io.WriteString(w, r.RequestURI)
+}
+
+func bearHandlerParams(path string) bear.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request, ctx *bear.Context) {
+		writeParams(w, path, func(name string, catchAll bool) string {
+			if catchAll {
+				return ctx.Params["*"]
+			}
+			return ctx.Params[name]
+		})
+	}
+}
+
+func loadBear(routes []route) http.Handler {
+	h := bearHandler
+	if loadTestHandler {
+		h = bearHandlerTest
+	}
+
+	router := bear.New()
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = bearHandlerParams(route.path)
+		}
+		bearOn(router, route.method, bearPath(route.path), h)
+	}
+	return router
+}
+
+func loadBearSingle(method string, path string, handler bear.HandlerFunc) http.Handler {
+	router := bear.New()
+	bearOn(router, method, path, handler)
+	return router
+}
+
+// bearPath translates the parameters of the route path.
+func bearPath(path string) string {
+	path = paramRe.ReplaceAllString(path, "{$1}")
+	return wildcardRe.ReplaceAllString(path, "*")
+}
+
+// bearOn registers the route, On fails for methods Bear doesn't know.
+func bearOn(router *bear.Mux, method, path string, handler bear.HandlerFunc) {
+	if err := router.On(method, path, handler); err != nil {
+		panic(err)
+	}
+}
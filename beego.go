@@ -0,0 +1,137 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"net/http"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+)
+
+func init() {
+	initBeego()
+	register(beegoAdapter{})
+}
+
+type beegoAdapter struct{}
+
+func (beegoAdapter) Name() string { return "Beego" }
+func (beegoAdapter) Features() Feature {
+	return FeatureParams | FeatureWildcard | FeatureMixedSegments | FeatureAdd
+}
+
+func (beegoAdapter) Unsupported(f Feature) string {
+	switch f {
+	case FeatureReverse:
+		return "URLFor only builds the paths of controller methods, not of handler functions"
+	}
+	return ""
+}
+
+func (beegoAdapter) Load(routes []route) http.Handler {
+	return loadBeego(routes)
+}
+
+func (beegoAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := beegoHandler
+	if write {
+		h = beegoHandlerWrite
+		if wildcardRe.MatchString(path) {
+			h = beegoHandlerWriteWildcard
+		}
+	}
+	path = wildcardRe.ReplaceAllString(path, "*")
+	return loadBeegoSingle(method, path, h)
+}
+
+func (beegoAdapter) Add(router http.Handler, r route) {
+	h := beegoHandler
+	if loadTestHandler {
+		h = beegoHandlerTest
+	}
+	router.(*beego.ControllerRegister).AddMethod(r.method, wildcardRe.ReplaceAllString(r.path, "*"), h)
+}
+
+func beegoHandler(ctx *context.Context) {}
+
+func beegoHandlerWrite(ctx *context.Context) {
+	ctx.WriteString(ctx.Input.Param(":name"))
+}
+
+func beegoHandlerWriteWildcard(ctx *context.Context) {
+	ctx.WriteString(ctx.Input.Param(":splat"))
+}
+
+func beegoHandlerTest(ctx *context.Context) {
+	ctx.WriteString(ctx.Request.RequestURI)
+}
+
+func initBeego() {
+	beego.BConfig.RunMode = beego.PROD
+	beego.BeeLogger.Close()
+}
+
+func beegoHandlerParams(path string) beego.FilterFunc {
+	return func(ctx *context.Context) {
+		writeParams(ctx.ResponseWriter, path, func(name string, catchAll bool) string {
+			if catchAll {
+				return ctx.Input.Param(":splat")
+			}
+			return ctx.Input.Param(":" + name)
+		})
+	}
+}
+
+func loadBeego(routes []route) http.Handler {
+	h := beegoHandler
+	if loadTestHandler {
+		h = beegoHandlerTest
+	}
+
+	app := beego.NewControllerRegister()
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = beegoHandlerParams(route.path)
+		}
+
+		path := wildcardRe.ReplaceAllString(route.path, "*")
+
+		switch route.method {
+		case http.MethodGet:
+			app.Get(path, h)
+		case http.MethodPost:
+			app.Post(path, h)
+		case http.MethodPut:
+			app.Put(path, h)
+		case http.MethodPatch:
+			app.Patch(path, h)
+		case http.MethodDelete:
+			app.Delete(path, h)
+		default:
+			panic("Unknow HTTP method: " + route.method)
+		}
+	}
+	return app
+}
+
+func loadBeegoSingle(method, path string, handler beego.FilterFunc) http.Handler {
+	app := beego.NewControllerRegister()
+	switch method {
+	case http.MethodGet:
+		app.Get(path, handler)
+	case http.MethodPost:
+		app.Post(path, handler)
+	case http.MethodPut:
+		app.Put(path, handler)
+	case http.MethodPatch:
+		app.Patch(path, handler)
+	case http.MethodDelete:
+		app.Delete(path, handler)
+	default:
+		panic("Unknow HTTP method: " + method)
+	}
+	return app
+}
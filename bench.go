@@ -0,0 +1,520 @@
+// Copyright 2013 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+// api is a set of routes modeled after a real world API.
+type api struct {
+	name   string
+	routes []route
+}
+
+// all APIs
+var apis = []api{
+	{"GitHub", githubAPI},
+	{"GPlus", gplusAPI},
+	{"Parse", parseAPI},
+	{"Static", staticRoutes},
+	{"Wildcard", wildcardAPI},
+}
+
+// findAPI returns the API with the given name.
+func findAPI(name string) api {
+	for _, api := range apis {
+		if api.name == name {
+			return api
+		}
+	}
+	panic("Unknown API: " + name)
+}
+
+// benchPrefix returns the prefix of the names of the API's benchmarks.
+func benchPrefix(apiName string) string {
+	if apiName == "GitHub" {
+		return "Github"
+	}
+	return apiName
+}
+
+// benchmark is run once for every router supporting it. The go test
+// benchmarks run the routers as sub-benchmarks, the command line runner
+// calls testing.Benchmark for each of them.
+type benchmark struct {
+	name string
+
+	// api is the name of the API loaded into the routers, empty for micro
+	// benchmarks
+	api string
+
+	// parallel benchmarks run once for each of the parallelProcs levels
+	parallel bool
+
+	supports func(a Adapter) bool
+	run      func(b *testing.B, a Adapter)
+}
+
+// procs returns the GOMAXPROCS levels the benchmark runs at, 0 if it keeps
+// the default.
+func (bm benchmark) procs() []int {
+	if !bm.parallel {
+		return []int{0}
+	}
+	return parallelProcs()
+}
+
+// runAt runs the benchmark with GOMAXPROCS set to procs, unless procs is 0.
+func (bm benchmark) runAt(b *testing.B, a Adapter, procs int) {
+	if procs > 0 {
+		defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+	}
+	bm.run(b, a)
+}
+
+// parallelProcs returns the GOMAXPROCS levels of the parallel benchmarks.
+func parallelProcs() []int {
+	procs := []int{1, 2, 4}
+	if n := runtime.NumCPU(); !slices.Contains(procs, n) {
+		procs = append(procs, n)
+		slices.Sort(procs)
+	}
+	return procs
+}
+
+// Route with 5 Params
+const fiveColon = "/:a/:b/:c/:d/:e"
+const fiveRoute = "/test/test/test/test/test"
+
+// Route with 20 Params
+const twentyColon = "/:a/:b/:c/:d/:e/:f/:g/:h/:i/:j/:k/:l/:m/:n/:o/:p/:q/:r/:s/:t"
+const twentyRoute = "/a/b/c/d/e/f/g/h/i/j/k/l/m/n/o/p/q/r/s/t"
+
+// all benchmarks
+var benchmarks = []benchmark{
+	// Micro Benchmarks
+	singleBenchmark("Param", "/user/:name", "/user/gordon", false),
+	singleBenchmark("Param5", fiveColon, fiveRoute, false),
+	singleBenchmark("Param20", twentyColon, twentyRoute, false),
+	singleBenchmark("ParamWrite", "/user/:name", "/user/gordon", true),
+
+	// GitHub
+	requestBenchmark("GithubStatic", "GitHub", http.MethodGet, "/user/repos"),
+	requestBenchmark("GithubParam", "GitHub", http.MethodGet, "/repos/julienschmidt/httprouter/stargazers"),
+	routesBenchmark("GithubAll", "GitHub"),
+	zipfBenchmark("GithubZipf", "GitHub"),
+	parallelRequestBenchmark("GithubParamParallel", "GitHub", http.MethodGet, "/repos/julienschmidt/httprouter/stargazers"),
+	parallelRoutesBenchmark("GithubAllParallel", "GitHub"),
+	notFoundBenchmark("GithubNotFound", "GitHub"),
+	methodNotAllowedBenchmark("GithubMethodNotAllowed", "GitHub"),
+	middlewareBenchmark("GithubMiddleware0", "GitHub", 0),
+	middlewareBenchmark("GithubMiddleware1", "GitHub", 1),
+	middlewareBenchmark("GithubMiddleware5", "GitHub", 5),
+	middlewareBenchmark("GithubMiddleware10", "GitHub", 10),
+	reverseBenchmark("GithubReverse", "GitHub"),
+	addBenchmark("GithubAdd", "GitHub"),
+	removeBenchmark("GithubRemove", "GitHub"),
+	loadBenchmark("GithubLoad", "GitHub"),
+
+	// Google+
+	requestBenchmark("GPlusStatic", "GPlus", http.MethodGet, "/people"),
+	requestBenchmark("GPlusParam", "GPlus", http.MethodGet, "/people/118051310819094153327"),
+	requestBenchmark("GPlus2Params", "GPlus", http.MethodGet, "/people/118051310819094153327/activities/123456789"),
+	routesBenchmark("GPlusAll", "GPlus"),
+	zipfBenchmark("GPlusZipf", "GPlus"),
+	parallelRequestBenchmark("GPlusParamParallel", "GPlus", http.MethodGet, "/people/118051310819094153327"),
+	parallelRoutesBenchmark("GPlusAllParallel", "GPlus"),
+	notFoundBenchmark("GPlusNotFound", "GPlus"),
+	methodNotAllowedBenchmark("GPlusMethodNotAllowed", "GPlus"),
+	reverseBenchmark("GPlusReverse", "GPlus"),
+	loadBenchmark("GPlusLoad", "GPlus"),
+
+	// Parse
+	requestBenchmark("ParseStatic", "Parse", http.MethodGet, "/1/users"),
+	requestBenchmark("ParseParam", "Parse", http.MethodGet, "/1/classes/go"),
+	requestBenchmark("Parse2Params", "Parse", http.MethodGet, "/1/classes/go/123456789"),
+	routesBenchmark("ParseAll", "Parse"),
+	zipfBenchmark("ParseZipf", "Parse"),
+	parallelRequestBenchmark("ParseParamParallel", "Parse", http.MethodGet, "/1/classes/go"),
+	parallelRoutesBenchmark("ParseAllParallel", "Parse"),
+	notFoundBenchmark("ParseNotFound", "Parse"),
+	methodNotAllowedBenchmark("ParseMethodNotAllowed", "Parse"),
+	reverseBenchmark("ParseReverse", "Parse"),
+	loadBenchmark("ParseLoad", "Parse"),
+
+	// Wildcard
+	requestBenchmark("WildcardParam", "Wildcard", http.MethodGet, "/repos/julienschmidt/httprouter/contents/"+wildcardValue),
+	routesBenchmark("WildcardAll", "Wildcard"),
+	notFoundBenchmark("WildcardNotFound", "Wildcard"),
+	loadBenchmark("WildcardLoad", "Wildcard"),
+
+	// Static
+	routesBenchmark("StaticAll", "Static"),
+	zipfBenchmark("StaticZipf", "Static"),
+	parallelRoutesBenchmark("StaticAllParallel", "Static"),
+	notFoundBenchmark("StaticNotFound", "Static"),
+	methodNotAllowedBenchmark("StaticMethodNotAllowed", "Static"),
+	loadBenchmark("StaticLoad", "Static"),
+}
+
+// findBenchmark returns the benchmark with the given name.
+func findBenchmark(name string) benchmark {
+	for _, bm := range benchmarks {
+		if bm.name == name {
+			return bm
+		}
+	}
+	panic("Unknown benchmark: " + name)
+}
+
+// singleBenchmark routes a request for reqPath with only the route path
+// loaded. If write is set, the handler writes the "name" parameter.
+func singleBenchmark(name, path, reqPath string, write bool) benchmark {
+	return benchmark{
+		name: name,
+		supports: func(a Adapter) bool {
+			return a.Features().Has(FeatureParams)
+		},
+		run: func(b *testing.B, a Adapter) {
+			router := a.LoadSingle(http.MethodGet, path, write)
+
+			r, _ := http.NewRequest(http.MethodGet, reqPath, nil)
+			benchRequest(b, router, r)
+		},
+	}
+}
+
+// requestBenchmark routes a single request with all routes of the API loaded.
+func requestBenchmark(name, apiName, method, path string) benchmark {
+	return benchmark{
+		name: name,
+		api:  apiName,
+		supports: func(a Adapter) bool {
+			return canServe(a, findAPI(apiName).routes)
+		},
+		run: func(b *testing.B, a Adapter) {
+			r, _ := http.NewRequest(method, path, nil)
+			benchRequest(b, apiHandler(findAPI(apiName), a), r)
+		},
+	}
+}
+
+// routesBenchmark routes all routes of the API once per operation.
+func routesBenchmark(name, apiName string) benchmark {
+	return benchmark{
+		name: name,
+		api:  apiName,
+		supports: func(a Adapter) bool {
+			return canServe(a, findAPI(apiName).routes)
+		},
+		run: func(b *testing.B, a Adapter) {
+			api := findAPI(apiName)
+			benchRoutes(b, apiHandler(api, a), requests(api.routes))
+		},
+	}
+}
+
+// parallelRequestBenchmark is the parallel variant of requestBenchmark.
+func parallelRequestBenchmark(name, apiName, method, path string) benchmark {
+	return benchmark{
+		name:     name,
+		api:      apiName,
+		parallel: true,
+		supports: func(a Adapter) bool {
+			return canServe(a, findAPI(apiName).routes)
+		},
+		run: func(b *testing.B, a Adapter) {
+			benchRequestParallel(b, apiHandler(findAPI(apiName), a), method, path)
+		},
+	}
+}
+
+// parallelRoutesBenchmark is the parallel variant of routesBenchmark.
+func parallelRoutesBenchmark(name, apiName string) benchmark {
+	return benchmark{
+		name:     name,
+		api:      apiName,
+		parallel: true,
+		supports: func(a Adapter) bool {
+			return canServe(a, findAPI(apiName).routes)
+		},
+		run: func(b *testing.B, a Adapter) {
+			api := findAPI(apiName)
+			benchRoutesParallel(b, apiHandler(api, a), requests(api.routes))
+		},
+	}
+}
+
+// notFoundBenchmark routes requests for paths of the API no route matches
+// once per operation, see notFoundRoutes.
+func notFoundBenchmark(name, apiName string) benchmark {
+	return benchmark{
+		name: name,
+		api:  apiName,
+		supports: func(a Adapter) bool {
+			return canServe(a, findAPI(apiName).routes) && !deviationOf(a).panics
+		},
+		run: func(b *testing.B, a Adapter) {
+			api := findAPI(apiName)
+			benchRoutes(b, apiHandler(api, a), notFoundRoutes(api.routes))
+		},
+	}
+}
+
+// methodNotAllowedBenchmark routes requests for the paths of the API with a
+// wrong method once per operation, see methodNotAllowedRoutes.
+func methodNotAllowedBenchmark(name, apiName string) benchmark {
+	return benchmark{
+		name: name,
+		api:  apiName,
+		supports: func(a Adapter) bool {
+			return canServe(a, findAPI(apiName).routes) && !deviationOf(a).panics
+		},
+		run: func(b *testing.B, a Adapter) {
+			api := findAPI(apiName)
+			benchRoutes(b, apiHandler(api, a), methodNotAllowedRoutes(api.routes))
+		},
+	}
+}
+
+// loadBenchmark builds a router from all routes of the API once per
+// operation, including any compilation step like Kocha's Build.
+func loadBenchmark(name, apiName string) benchmark {
+	return benchmark{
+		name: name,
+		api:  apiName,
+		supports: func(a Adapter) bool {
+			return canServe(a, findAPI(apiName).routes)
+		},
+		run: func(b *testing.B, a Adapter) {
+			routes := findAPI(apiName).routes
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				a.Load(routes)
+			}
+		},
+	}
+}
+
+// memRepetitions is the number of times a router is loaded to measure its
+// memory consumption.
+const memRepetitions = 5
+
+// memStats is the memory consumption of loading a router, averaged over
+// memRepetitions loads.
+type memStats struct {
+	// AllocBytes and Allocs are the bytes and objects allocated while
+	// loading, including garbage
+	AllocBytes uint64
+	Allocs     uint64
+
+	// HeapBytes and HeapObjects are the bytes and objects still reachable
+	// from the loaded router
+	HeapBytes   uint64
+	HeapObjects uint64
+}
+
+// fullGC runs the garbage collector until everything unreachable is freed.
+// A single cycle only moves the contents of sync.Pools to their victim
+// caches.
+func fullGC() {
+	runtime.GC()
+	runtime.GC()
+}
+
+// measureMem loads a router memRepetitions times and returns the average
+// memory consumption. The router returned by load is kept reachable until the
+// heap is measured after loading.
+//
+// The heap is shared with all goroutines, so anything else allocating or
+// freeing memory at the same time distorts the result. If the heap shrinks
+// while loading, the retained memory can't be determined and an error is
+// returned.
+func measureMem(load func() http.Handler) (memStats, error) {
+	var sum memStats
+	var before, after runtime.MemStats
+	for i := 0; i < memRepetitions; i++ {
+		fullGC()
+		runtime.ReadMemStats(&before)
+
+		h := load()
+
+		fullGC()
+		runtime.ReadMemStats(&after)
+		runtime.KeepAlive(h)
+
+		if after.HeapAlloc < before.HeapAlloc || after.HeapObjects < before.HeapObjects {
+			return memStats{}, fmt.Errorf("heap shrank while loading, from %d to %d bytes in %d to %d objects",
+				before.HeapAlloc, after.HeapAlloc, before.HeapObjects, after.HeapObjects)
+		}
+		sum.AllocBytes += after.TotalAlloc - before.TotalAlloc
+		sum.Allocs += after.Mallocs - before.Mallocs
+		sum.HeapBytes += after.HeapAlloc - before.HeapAlloc
+		sum.HeapObjects += after.HeapObjects - before.HeapObjects
+	}
+	return memStats{
+		AllocBytes:  sum.AllocBytes / memRepetitions,
+		Allocs:      sum.Allocs / memRepetitions,
+		HeapBytes:   sum.HeapBytes / memRepetitions,
+		HeapObjects: sum.HeapObjects / memRepetitions,
+	}, nil
+}
+
+// String formats the memory consumption for the output of the benchmarks.
+func (m memStats) String() string {
+	return fmt.Sprintf("%d Bytes in %d objects (%d Bytes in %d allocs while loading)",
+		m.HeapBytes, m.HeapObjects, m.AllocBytes, m.Allocs)
+}
+
+// apiHandlers holds the loaded routers of each API, keyed by API and router
+// name.
+var apiHandlers = make(map[string]map[string]http.Handler)
+
+// apiHandler returns the router a loaded with the routes of api.
+func apiHandler(api api, a Adapter) http.Handler {
+	handlers := apiHandlers[api.name]
+	if handlers == nil {
+		handlers = make(map[string]http.Handler)
+		apiHandlers[api.name] = handlers
+	}
+	h, ok := handlers[a.Name()]
+	if !ok {
+		h = a.Load(api.routes)
+		handlers[a.Name()] = h
+	}
+	return h
+}
+
+func benchRequest(b *testing.B, router http.Handler, r *http.Request) {
+	if latencyMode {
+		benchLatency(b, router, []route{{r.Method, r.URL.Path}}, 1)
+		return
+	}
+
+	w := selectedWriter.new()
+	u := r.URL
+	rq := u.RawQuery
+	r.RequestURI = u.RequestURI()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		u.RawQuery = rq
+		router.ServeHTTP(w, r)
+		w.finish()
+	}
+}
+
+func benchRoutes(b *testing.B, router http.Handler, routes []route) {
+	if latencyMode {
+		benchLatency(b, router, routes, len(routes))
+		return
+	}
+
+	w := selectedWriter.new()
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+	u := r.URL
+	rq := u.RawQuery
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, route := range routes {
+			r.Method = route.method
+			r.RequestURI = route.path
+			u.Path = route.path
+			u.RawQuery = rq
+			router.ServeHTTP(w, r)
+			w.finish()
+		}
+	}
+}
+
+// benchStream routes one request of the stream per operation, starting over
+// at its end.
+func benchStream(b *testing.B, router http.Handler, reqs []route) {
+	if latencyMode {
+		benchLatency(b, router, reqs, 1)
+		return
+	}
+
+	w := selectedWriter.new()
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+	u := r.URL
+	rq := u.RawQuery
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	next := 0
+	for i := 0; i < b.N; i++ {
+		route := reqs[next]
+		if next++; next == len(reqs) {
+			next = 0
+		}
+		r.Method = route.method
+		r.RequestURI = route.path
+		u.Path = route.path
+		u.RawQuery = rq
+		router.ServeHTTP(w, r)
+		w.finish()
+	}
+}
+
+// benchRequestParallel is the parallel variant of benchRequest. Each
+// goroutine routes its own request.
+func benchRequestParallel(b *testing.B, router http.Handler, method, path string) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		w := selectedWriter.new()
+		r, _ := http.NewRequest(method, path, nil)
+		u := r.URL
+		rq := u.RawQuery
+		r.RequestURI = u.RequestURI()
+
+		for pb.Next() {
+			u.RawQuery = rq
+			router.ServeHTTP(w, r)
+			w.finish()
+		}
+	})
+}
+
+// benchRoutesParallel is the parallel variant of benchRoutes. Each goroutine
+// routes all routes with its own request per operation.
+func benchRoutesParallel(b *testing.B, router http.Handler, routes []route) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		w := selectedWriter.new()
+		r, _ := http.NewRequest(http.MethodGet, "/", nil)
+		u := r.URL
+		rq := u.RawQuery
+
+		for pb.Next() {
+			for _, route := range routes {
+				r.Method = route.method
+				r.RequestURI = route.path
+				u.Path = route.path
+				u.RawQuery = rq
+				router.ServeHTTP(w, r)
+				w.finish()
+			}
+		}
+	})
+}
@@ -5,10 +5,11 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"net/http"
 	"os"
 	"regexp"
-	"runtime"
 	"strings"
 	"testing"
 )
@@ -17,17 +18,14 @@ var benchRe *regexp.Regexp
 
 func isTested(name string) bool {
 	if benchRe == nil {
-		// Get -test.bench flag value (not accessible via flag package)
+		// The routers are sub-benchmarks, so only the part of the
+		// -test.bench flag value after the first slash selects them
 		bench := ""
-		for _, arg := range os.Args {
-			if strings.HasPrefix(arg, "-test.bench=") {
-				// ignore the benchmark name after an underscore
-				bench = strings.SplitN(arg[12:], "_", 2)[0]
-				break
-			}
+		if parts := strings.SplitN(flag.Lookup("test.bench").Value.String(), "/", 3); len(parts) > 1 {
+			bench = parts[1]
 		}
 
-		// Compile RegExp to match Benchmark names
+		// Compile RegExp to match router names
 		var err error
 		benchRe, err = regexp.Compile(bench)
 		if err != nil {
@@ -37,850 +35,119 @@ func isTested(name string) bool {
 	return benchRe.MatchString(name)
 }
 
-func calcMem(name string, load func()) {
+func calcMem(name string, load func() http.Handler) {
 	if !isTested(name) {
 		return
 	}
-
-	m := new(runtime.MemStats)
-
-	// before
-	// force GC multiple times, since Go is using a generational GC
-	// TODO: find a better approach
-	runtime.GC()
-	runtime.GC()
-	runtime.GC()
-	runtime.GC()
-	runtime.ReadMemStats(m)
-	before := m.HeapAlloc
-
-	load()
-
-	// after
-	runtime.GC()
-	runtime.GC()
-	runtime.GC()
-	runtime.GC()
-	runtime.ReadMemStats(m)
-	after := m.HeapAlloc
-	println("   "+name+":", after-before, "Bytes")
-}
-
-func benchRequest(b *testing.B, router http.Handler, r *http.Request) {
-	w := new(mockResponseWriter)
-	u := r.URL
-	rq := u.RawQuery
-	r.RequestURI = u.RequestURI()
-
-	b.ReportAllocs()
-	b.ResetTimer()
-
-	for i := 0; i < b.N; i++ {
-		u.RawQuery = rq
-		router.ServeHTTP(w, r)
+	m, err := measureMem(load)
+	if err != nil {
+		println("   "+name+": error:", err.Error())
+		return
 	}
+	println("   "+name+":", m.String())
 }
 
-func benchRoutes(b *testing.B, router http.Handler, routes []route) {
-	w := new(mockResponseWriter)
-	r, _ := http.NewRequest(http.MethodGet, "/", nil)
-	u := r.URL
-	rq := u.RawQuery
+func TestMain(m *testing.M) {
+	opts.register(flag.CommandLine)
+	flag.Parse()
 
-	b.ReportAllocs()
-	b.ResetTimer()
+	if err := opts.apply(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
 
-	for i := 0; i < b.N; i++ {
-		for _, route := range routes {
-			r.Method = route.method
-			r.RequestURI = route.path
-			u.Path = route.path
-			u.RawQuery = rq
-			router.ServeHTTP(w, r)
+	// Load the APIs up front when benchmarking, to report the memory
+	// consumption of each router
+	if flag.Lookup("test.bench").Value.String() != "" {
+		for _, api := range apis {
+			println("#"+api.name+" Routes:", len(api.routes))
+			for _, a := range adapters {
+				if canServe(a, api.routes) {
+					calcMem(a.Name(), func() http.Handler {
+						return a.Load(api.routes)
+					})
+					apiHandler(api, a)
+				}
+			}
+			println()
 		}
 	}
-}
-
-// Micro Benchmarks
-
-// Route with Param (no write)
-func BenchmarkAce_Param(b *testing.B) {
-	router := loadAceSingle(http.MethodGet, "/user/:name", aceHandle)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkBear_Param(b *testing.B) {
-	router := loadBearSingle(http.MethodGet, "/user/{name}", bearHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBeego_Param(b *testing.B) {
-	router := loadBeegoSingle(http.MethodGet, "/user/:name", beegoHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBone_Param(b *testing.B) {
-	router := loadBoneSingle(http.MethodGet, "/user/:name", http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkChi_Param(b *testing.B) {
-	router := loadChiSingle(http.MethodGet, "/user/{name}", httpHandlerFunc)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkSuperhttp_Param(b *testing.B) {
-	router := loadSuperhttpSingle(http.MethodGet, "/user/{name}", httpHandlerFunc)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkDenco_Param(b *testing.B) {
-	router := loadDencoSingle(http.MethodGet, "/user/:name", dencoHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkEcho_Param(b *testing.B) {
-	router := loadEchoSingle(http.MethodGet, "/user/:name", echoHandler)
 
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
+	os.Exit(m.Run())
 }
-func BenchmarkGin_Param(b *testing.B) {
-	router := loadGinSingle(http.MethodGet, "/user/:name", ginHandle)
 
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGocraftWeb_Param(b *testing.B) {
-	router := loadGocraftWebSingle(http.MethodGet, "/user/:name", gocraftWebHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoji_Param(b *testing.B) {
-	router := loadGojiSingle(http.MethodGet, "/user/:name", httpHandlerFunc)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGojiv2_Param(b *testing.B) {
-	router := loadGojiv2Single(http.MethodGet, "/user/:name", gojiv2Handler)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoJsonRest_Param(b *testing.B) {
-	router := loadGoJsonRestSingle(http.MethodGet, "/user/:name", goJsonRestHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoRestful_Param(b *testing.B) {
-	router := loadGoRestfulSingle(http.MethodGet, "/user/{name}", goRestfulHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGorillaMux_Param(b *testing.B) {
-	router := loadGorillaMuxSingle(http.MethodGet, "/user/{name}", httpHandlerFunc)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGowwwRouter_Param(b *testing.B) {
-	router := loadGowwwRouterSingle(http.MethodGet, "/user/:name", http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkHttpRouter_Param(b *testing.B) {
-	router := loadHttpRouterSingle(http.MethodGet, "/user/:name", httpRouterHandle)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkHttpTreeMux_Param(b *testing.B) {
-	router := loadHttpTreeMuxSingle(http.MethodGet, "/user/:name", httpTreeMuxHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkKocha_Param(b *testing.B) {
-	handler := new(kochaHandler)
-	router := loadKochaSingle(
-		http.MethodGet, "/user/:name",
-		handler, http.HandlerFunc(handler.Get),
-	)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkLARS_Param(b *testing.B) {
-	router := loadLARSSingle(http.MethodGet, "/user/:name", larsHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkMacaron_Param(b *testing.B) {
-	router := loadMacaronSingle(http.MethodGet, "/user/:name", macaronHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkMartini_Param(b *testing.B) {
-	router := loadMartiniSingle(http.MethodGet, "/user/:name", martiniHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkPat_Param(b *testing.B) {
-	router := loadPatSingle(http.MethodGet, "/user/:name", http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
+// runBenchmark runs the named benchmark with each supporting router as a
+// sub-benchmark. Parallel benchmarks have a sub-benchmark of the router for
+// each GOMAXPROCS level.
+func runBenchmark(b *testing.B, name string) {
+	bm := findBenchmark(name)
+	for _, a := range adapters {
+		if !bm.supports(a) {
+			continue
+		}
+		if !bm.parallel {
+			b.Run(a.Name(), func(b *testing.B) {
+				bm.run(b, a)
+			})
+			continue
+		}
+		b.Run(a.Name(), func(b *testing.B) {
+			for _, procs := range bm.procs() {
+				b.Run(fmt.Sprintf("procs=%d", procs), func(b *testing.B) {
+					bm.runAt(b, a, procs)
+				})
+			}
+		})
+	}
 }
 
-func BenchmarkR2router_Param(b *testing.B) {
-	router := loadR2routerSingle(http.MethodGet, "/user/:name", r2routerHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
+type memTestHandler struct {
+	data []byte
 }
 
-// func BenchmarkRevel_Param(b *testing.B) {
-// 	router := loadRevelSingle(http.MethodGet, "/user/:name", "RevelController.Handle")
-
-//		r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-//		benchRequest(b, router, r)
-//	}
-func BenchmarkRivet_Param(b *testing.B) {
-	router := loadRivetSingle(http.MethodGet, "/user/:name", rivetHandler)
+func (h *memTestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
 
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkTigerTonic_Param(b *testing.B) {
-	router := loadTigerTonicSingle(http.MethodGet, "/user/{name}", httpHandlerFunc)
+var memTestGarbage []byte
 
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
+func TestMeasureMem(t *testing.T) {
+	const size = 1 << 20
+	m, err := measureMem(func() http.Handler {
+		memTestGarbage = make([]byte, size)
+		memTestGarbage = nil
+		return &memTestHandler{data: make([]byte, size)}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.HeapBytes < size || m.HeapBytes > size+size/10 {
+		t.Errorf("retained %d bytes, expected about %d", m.HeapBytes, size)
+	}
+	if m.AllocBytes < 2*size {
+		t.Errorf("allocated %d bytes, expected at least %d", m.AllocBytes, 2*size)
+	}
+	if m.Allocs < 3 || m.HeapObjects < 2 {
+		t.Errorf("got %d allocs and %d retained objects, expected at least 3 and 2", m.Allocs, m.HeapObjects)
+	}
 }
-func BenchmarkTraffic_Param(b *testing.B) {
-	router := loadTrafficSingle(http.MethodGet, "/user/:name", trafficHandler)
 
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkVulcan_Param(b *testing.B) {
-	router := loadVulcanSingle(http.MethodGet, "/user/:name", vulcanHandler)
+// Micro Benchmarks
 
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
+// Route with Param (no write)
+func BenchmarkParam(b *testing.B) {
+	runBenchmark(b, "Param")
 }
 
-// func BenchmarkZeus_Param(b *testing.B) {
-// 	router := loadZeusSingle(http.MethodGet, "/user/:name", http.HandlerFunc(httpHandlerFunc))
-
-// 	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-// 	benchRequest(b, router, r)
-// }
-
 // Route with 5 Params (no write)
-const fiveColon = "/:a/:b/:c/:d/:e"
-const fiveBrace = "/{a}/{b}/{c}/{d}/{e}"
-const fiveRoute = "/test/test/test/test/test"
-
-func BenchmarkAce_Param5(b *testing.B) {
-	router := loadAceSingle(http.MethodGet, fiveColon, aceHandle)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkBear_Param5(b *testing.B) {
-	router := loadBearSingle(http.MethodGet, fiveBrace, bearHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBeego_Param5(b *testing.B) {
-	router := loadBeegoSingle(http.MethodGet, fiveColon, beegoHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBone_Param5(b *testing.B) {
-	router := loadBoneSingle(http.MethodGet, fiveColon, http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkChi_Param5(b *testing.B) {
-	router := loadChiSingle(http.MethodGet, fiveBrace, httpHandlerFunc)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkSuperhttp_Param5(b *testing.B) {
-	router := loadSuperhttpSingle(http.MethodGet, fiveBrace, httpHandlerFunc)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkDenco_Param5(b *testing.B) {
-	router := loadDencoSingle(http.MethodGet, fiveColon, dencoHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkEcho_Param5(b *testing.B) {
-	router := loadEchoSingle(http.MethodGet, fiveColon, echoHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGin_Param5(b *testing.B) {
-	router := loadGinSingle(http.MethodGet, fiveColon, ginHandle)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGocraftWeb_Param5(b *testing.B) {
-	router := loadGocraftWebSingle(http.MethodGet, fiveColon, gocraftWebHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoji_Param5(b *testing.B) {
-	router := loadGojiSingle(http.MethodGet, fiveColon, httpHandlerFunc)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGojiv2_Param5(b *testing.B) {
-	router := loadGojiv2Single(http.MethodGet, fiveColon, gojiv2Handler)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoJsonRest_Param5(b *testing.B) {
-	handler := loadGoJsonRestSingle(http.MethodGet, fiveColon, goJsonRestHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, handler, r)
-}
-func BenchmarkGoRestful_Param5(b *testing.B) {
-	router := loadGoRestfulSingle(http.MethodGet, fiveBrace, goRestfulHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGorillaMux_Param5(b *testing.B) {
-	router := loadGorillaMuxSingle(http.MethodGet, fiveBrace, httpHandlerFunc)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGowwwRouter_Param5(b *testing.B) {
-	router := loadGowwwRouterSingle(http.MethodGet, fiveColon, http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkHttpRouter_Param5(b *testing.B) {
-	router := loadHttpRouterSingle(http.MethodGet, fiveColon, httpRouterHandle)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkHttpTreeMux_Param5(b *testing.B) {
-	router := loadHttpTreeMuxSingle(http.MethodGet, fiveColon, httpTreeMuxHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkKocha_Param5(b *testing.B) {
-	handler := new(kochaHandler)
-	router := loadKochaSingle(
-		http.MethodGet, fiveColon,
-		handler, http.HandlerFunc(handler.Get),
-	)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkLARS_Param5(b *testing.B) {
-	router := loadLARSSingle(http.MethodGet, fiveColon, larsHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkMacaron_Param5(b *testing.B) {
-	router := loadMacaronSingle(http.MethodGet, fiveColon, macaronHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkMartini_Param5(b *testing.B) {
-	router := loadMartiniSingle(http.MethodGet, fiveColon, martiniHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkPat_Param5(b *testing.B) {
-	router := loadPatSingle(http.MethodGet, fiveColon, http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkR2router_Param5(b *testing.B) {
-	router := loadR2routerSingle(http.MethodGet, fiveColon, r2routerHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-
-// func BenchmarkRevel_Param5(b *testing.B) {
-// 	router := loadRevelSingle(http.MethodGet, fiveColon, "RevelController.Handle")
-
-//		r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-//		benchRequest(b, router, r)
-//	}
-func BenchmarkRivet_Param5(b *testing.B) {
-	router := loadRivetSingle(http.MethodGet, fiveColon, rivetHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkTigerTonic_Param5(b *testing.B) {
-	router := loadTigerTonicSingle(http.MethodGet, fiveBrace, httpHandlerFunc)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
+func BenchmarkParam5(b *testing.B) {
+	runBenchmark(b, "Param5")
 }
-func BenchmarkTraffic_Param5(b *testing.B) {
-	router := loadTrafficSingle(http.MethodGet, fiveColon, trafficHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkVulcan_Param5(b *testing.B) {
-	router := loadVulcanSingle(http.MethodGet, fiveColon, vulcanHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-	benchRequest(b, router, r)
-}
-
-// func BenchmarkZeus_Param5(b *testing.B) {
-// 	router := loadZeusSingle(http.MethodGet, fiveColon, http.HandlerFunc(httpHandlerFunc))
-
-// 	r, _ := http.NewRequest(http.MethodGet, fiveRoute, nil)
-// 	benchRequest(b, router, r)
-// }
 
 // Route with 20 Params (no write)
-const twentyColon = "/:a/:b/:c/:d/:e/:f/:g/:h/:i/:j/:k/:l/:m/:n/:o/:p/:q/:r/:s/:t"
-const twentyBrace = "/{a}/{b}/{c}/{d}/{e}/{f}/{g}/{h}/{i}/{j}/{k}/{l}/{m}/{n}/{o}/{p}/{q}/{r}/{s}/{t}"
-const twentyRoute = "/a/b/c/d/e/f/g/h/i/j/k/l/m/n/o/p/q/r/s/t"
-
-func BenchmarkAce_Param20(b *testing.B) {
-	router := loadAceSingle(http.MethodGet, twentyColon, aceHandle)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkBear_Param20(b *testing.B) {
-	router := loadBearSingle(http.MethodGet, twentyBrace, bearHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBeego_Param20(b *testing.B) {
-	router := loadBeegoSingle(http.MethodGet, twentyColon, beegoHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBone_Param20(b *testing.B) {
-	router := loadBoneSingle(http.MethodGet, twentyColon, http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkChi_Param20(b *testing.B) {
-	router := loadChiSingle(http.MethodGet, twentyBrace, httpHandlerFunc)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkSuperhttp_Param20(b *testing.B) {
-	router := loadSuperhttpSingle(http.MethodGet, twentyBrace, httpHandlerFunc)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkDenco_Param20(b *testing.B) {
-	router := loadDencoSingle(http.MethodGet, twentyColon, dencoHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkEcho_Param20(b *testing.B) {
-	router := loadEchoSingle(http.MethodGet, twentyColon, echoHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGin_Param20(b *testing.B) {
-	router := loadGinSingle(http.MethodGet, twentyColon, ginHandle)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGocraftWeb_Param20(b *testing.B) {
-	router := loadGocraftWebSingle(http.MethodGet, twentyColon, gocraftWebHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoji_Param20(b *testing.B) {
-	router := loadGojiSingle(http.MethodGet, twentyColon, httpHandlerFunc)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGojiv2_Param20(b *testing.B) {
-	router := loadGojiv2Single(http.MethodGet, twentyColon, gojiv2Handler)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoJsonRest_Param20(b *testing.B) {
-	handler := loadGoJsonRestSingle(http.MethodGet, twentyColon, goJsonRestHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, handler, r)
-}
-func BenchmarkGoRestful_Param20(b *testing.B) {
-	handler := loadGoRestfulSingle(http.MethodGet, twentyBrace, goRestfulHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, handler, r)
-}
-func BenchmarkGorillaMux_Param20(b *testing.B) {
-	router := loadGorillaMuxSingle(http.MethodGet, twentyBrace, httpHandlerFunc)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGowwwRouter_Param20(b *testing.B) {
-	router := loadGowwwRouterSingle(http.MethodGet, twentyColon, http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkHttpRouter_Param20(b *testing.B) {
-	router := loadHttpRouterSingle(http.MethodGet, twentyColon, httpRouterHandle)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkHttpTreeMux_Param20(b *testing.B) {
-	router := loadHttpTreeMuxSingle(http.MethodGet, twentyColon, httpTreeMuxHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkKocha_Param20(b *testing.B) {
-	handler := new(kochaHandler)
-	router := loadKochaSingle(
-		http.MethodGet, twentyColon,
-		handler, http.HandlerFunc(handler.Get),
-	)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkLARS_Param20(b *testing.B) {
-	router := loadLARSSingle(http.MethodGet, twentyColon, larsHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkMacaron_Param20(b *testing.B) {
-	router := loadMacaronSingle(http.MethodGet, twentyColon, macaronHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkMartini_Param20(b *testing.B) {
-	router := loadMartiniSingle(http.MethodGet, twentyColon, martiniHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkPat_Param20(b *testing.B) {
-	router := loadPatSingle(http.MethodGet, twentyColon, http.HandlerFunc(httpHandlerFunc))
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkR2router_Param20(b *testing.B) {
-	router := loadR2routerSingle(http.MethodGet, twentyColon, r2routerHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-
-// func BenchmarkRevel_Param20(b *testing.B) {
-// 	router := loadRevelSingle(http.MethodGet, twentyColon, "RevelController.Handle")
-
-//		r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-//		benchRequest(b, router, r)
-//	}
-func BenchmarkRivet_Param20(b *testing.B) {
-	router := loadRivetSingle(http.MethodGet, twentyColon, rivetHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkTigerTonic_Param20(b *testing.B) {
-	router := loadTigerTonicSingle(http.MethodGet, twentyBrace, httpHandlerFunc)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkTraffic_Param20(b *testing.B) {
-	router := loadTrafficSingle(http.MethodGet, twentyColon, trafficHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkVulcan_Param20(b *testing.B) {
-	router := loadVulcanSingle(http.MethodGet, twentyColon, vulcanHandler)
-
-	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-	benchRequest(b, router, r)
+func BenchmarkParam20(b *testing.B) {
+	runBenchmark(b, "Param20")
 }
 
-// func BenchmarkZeus_Param20(b *testing.B) {
-// 	router := loadZeusSingle(http.MethodGet, twentyColon, http.HandlerFunc(httpHandlerFunc))
-
-// 	r, _ := http.NewRequest(http.MethodGet, twentyRoute, nil)
-// 	benchRequest(b, router, r)
-// }
-
 // Route with Param and write
-func BenchmarkAce_ParamWrite(b *testing.B) {
-	router := loadAceSingle(http.MethodGet, "/user/:name", aceHandleWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkBear_ParamWrite(b *testing.B) {
-	router := loadBearSingle(http.MethodGet, "/user/{name}", bearHandlerWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBeego_ParamWrite(b *testing.B) {
-	router := loadBeegoSingle(http.MethodGet, "/user/:name", beegoHandlerWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkBone_ParamWrite(b *testing.B) {
-	router := loadBoneSingle(http.MethodGet, "/user/:name", http.HandlerFunc(boneHandlerWrite))
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkChi_ParamWrite(b *testing.B) {
-	router := loadChiSingle(http.MethodGet, "/user/{name}", chiHandleWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkSuperhttp_ParamWrite(b *testing.B) {
-	router := loadSuperhttpSingle(http.MethodGet, "/user/{name}", superhttpHandleWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkDenco_ParamWrite(b *testing.B) {
-	router := loadDencoSingle(http.MethodGet, "/user/:name", dencoHandlerWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkEcho_ParamWrite(b *testing.B) {
-	router := loadEchoSingle(http.MethodGet, "/user/:name", echoHandlerWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGin_ParamWrite(b *testing.B) {
-	router := loadGinSingle(http.MethodGet, "/user/:name", ginHandleWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGocraftWeb_ParamWrite(b *testing.B) {
-	router := loadGocraftWebSingle(http.MethodGet, "/user/:name", gocraftWebHandlerWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoji_ParamWrite(b *testing.B) {
-	router := loadGojiSingle(http.MethodGet, "/user/:name", gojiFuncWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGojiv2_ParamWrite(b *testing.B) {
-	router := loadGojiv2Single(http.MethodGet, "/user/:name", gojiv2HandlerWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGoJsonRest_ParamWrite(b *testing.B) {
-	handler := loadGoJsonRestSingle(http.MethodGet, "/user/:name", goJsonRestHandlerWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, handler, r)
-}
-func BenchmarkGoRestful_ParamWrite(b *testing.B) {
-	handler := loadGoRestfulSingle(http.MethodGet, "/user/{name}", goRestfulHandlerWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, handler, r)
-}
-func BenchmarkGorillaMux_ParamWrite(b *testing.B) {
-	router := loadGorillaMuxSingle(http.MethodGet, "/user/{name}", gorillaHandlerWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkGowwwRouter_ParamWrite(b *testing.B) {
-	router := loadGowwwRouterSingle(http.MethodGet, "/user/:name", http.HandlerFunc(gowwwRouterHandleWrite))
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkHttpRouter_ParamWrite(b *testing.B) {
-	router := loadHttpRouterSingle(http.MethodGet, "/user/:name", httpRouterHandleWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkHttpTreeMux_ParamWrite(b *testing.B) {
-	router := loadHttpTreeMuxSingle(http.MethodGet, "/user/:name", httpTreeMuxHandlerWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkKocha_ParamWrite(b *testing.B) {
-	handler := new(kochaHandler)
-	router := loadKochaSingle(
-		http.MethodGet, "/user/:name",
-		handler, http.HandlerFunc(handler.kochaHandlerWrite),
-	)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkLARS_ParamWrite(b *testing.B) {
-	router := loadLARSSingle(http.MethodGet, "/user/:name", larsHandlerWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkMacaron_ParamWrite(b *testing.B) {
-	router := loadMacaronSingle(http.MethodGet, "/user/:name", macaronHandlerWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkMartini_ParamWrite(b *testing.B) {
-	router := loadMartiniSingle(http.MethodGet, "/user/:name", martiniHandlerWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
+func BenchmarkParamWrite(b *testing.B) {
+	runBenchmark(b, "ParamWrite")
 }
-func BenchmarkPat_ParamWrite(b *testing.B) {
-	router := loadPatSingle(http.MethodGet, "/user/:name", http.HandlerFunc(patHandlerWrite))
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-
-func BenchmarkR2router_ParamWrite(b *testing.B) {
-	router := loadR2routerSingle(http.MethodGet, "/user/:name", r2routerHandleWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-
-// func BenchmarkRevel_ParamWrite(b *testing.B) {
-// 	router := loadRevelSingle(http.MethodGet, "/user/:name", "RevelController.HandleWrite")
-
-//		r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-//		benchRequest(b, router, r)
-//	}
-func BenchmarkRivet_ParamWrite(b *testing.B) {
-	router := loadRivetSingle(http.MethodGet, "/user/:name", rivetHandlerWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkTigerTonic_ParamWrite(b *testing.B) {
-	router := loadTigerTonicSingle(
-		http.MethodGet, "/user/{name}",
-		http.HandlerFunc(tigerTonicHandlerWrite),
-	)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkTraffic_ParamWrite(b *testing.B) {
-	router := loadTrafficSingle(http.MethodGet, "/user/:name", trafficHandlerWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-func BenchmarkVulcan_ParamWrite(b *testing.B) {
-	router := loadVulcanSingle(http.MethodGet, "/user/:name", vulcanHandlerWrite)
-
-	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-	benchRequest(b, router, r)
-}
-
-// func BenchmarkZeus_ParamWrite(b *testing.B) {
-// 	router := loadZeusSingle(http.MethodGet, "/user/:name", zeusHandlerWrite)
-
-// 	r, _ := http.NewRequest(http.MethodGet, "/user/gordon", nil)
-// 	benchRequest(b, router, r)
-// }
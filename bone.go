@@ -0,0 +1,112 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/go-zoo/bone"
+)
+
+func init() {
+	register(boneAdapter{})
+}
+
+type boneAdapter struct{}
+
+func (boneAdapter) Name() string { return "Bone" }
+func (boneAdapter) Features() Feature {
+	return FeatureParams | FeatureMixedSegments | FeatureAdd
+}
+
+func (boneAdapter) Unsupported(f Feature) string {
+	switch f {
+	case FeatureWildcard:
+		return "the remainder matched by * is not available to the handler"
+	}
+	return ""
+}
+
+func (boneAdapter) Load(routes []route) http.Handler {
+	return loadBone(routes)
+}
+
+func (boneAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := http.HandlerFunc(httpHandlerFunc)
+	if write {
+		h = boneHandlerWrite
+	}
+	return loadBoneSingle(method, path, h)
+}
+
+func (boneAdapter) Add(router http.Handler, r route) {
+	h := http.HandlerFunc(httpHandlerFunc)
+	if loadTestHandler {
+		h = http.HandlerFunc(httpHandlerFuncTest)
+	}
+	router.(*bone.Mux).Register(r.method, r.path, h)
+}
+
+func boneHandlerWrite(rw http.ResponseWriter, req *http.Request) {
+	io.WriteString(rw, bone.GetValue(req, "name"))
+}
+
+func boneHandlerParams(path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeParams(w, path, func(name string, _ bool) string {
+			return bone.GetValue(r, name)
+		})
+	}
+}
+
+func loadBone(routes []route) http.Handler {
+	h := http.HandlerFunc(httpHandlerFunc)
+	if loadTestHandler {
+		h = http.HandlerFunc(httpHandlerFuncTest)
+	}
+
+	router := bone.New()
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = boneHandlerParams(route.path)
+		}
+
+		switch route.method {
+		case http.MethodGet:
+			router.Get(route.path, h)
+		case http.MethodPost:
+			router.Post(route.path, h)
+		case http.MethodPut:
+			router.Put(route.path, h)
+		case http.MethodPatch:
+			router.Patch(route.path, h)
+		case http.MethodDelete:
+			router.Delete(route.path, h)
+		default:
+			panic("Unknow HTTP method: " + route.method)
+		}
+	}
+	return router
+}
+
+func loadBoneSingle(method, path string, handler http.Handler) http.Handler {
+	router := bone.New()
+	switch method {
+	case http.MethodGet:
+		router.Get(path, handler)
+	case http.MethodPost:
+		router.Post(path, handler)
+	case http.MethodPut:
+		router.Put(path, handler)
+	case http.MethodPatch:
+		router.Patch(path, handler)
+	case http.MethodDelete:
+		router.Delete(path, handler)
+	default:
+		panic("Unknow HTTP method: " + method)
+	}
+	return router
+}
@@ -0,0 +1,126 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func init() {
+	register(chiAdapter{})
+}
+
+type chiAdapter struct{}
+
+func (chiAdapter) Name() string { return "Chi" }
+func (chiAdapter) Features() Feature {
+	return FeatureParams | FeatureMethodNotAllowed | FeatureWildcard | FeatureMixedSegments |
+		FeatureMiddleware | FeatureAdd
+}
+
+func (chiAdapter) Load(routes []route) http.Handler {
+	return loadChi(routes)
+}
+
+func (chiAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := httpHandlerFunc
+	if write {
+		h = chiHandleWrite
+		if wildcardRe.MatchString(path) {
+			h = chiHandleWriteWildcard
+		}
+	}
+	path = paramRe.ReplaceAllString(path, "{$1}")
+	path = wildcardRe.ReplaceAllString(path, "*")
+	return loadChiSingle(method, path, h)
+}
+
+func (chiAdapter) Add(router http.Handler, r route) {
+	h := httpHandlerFunc
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+	path := paramRe.ReplaceAllString(r.path, "{$1}")
+	path = wildcardRe.ReplaceAllString(path, "*")
+	router.(*chi.Mux).MethodFunc(r.method, path, h)
+}
+
+func chiHandleWrite(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, chi.URLParam(r, "name"))
+}
+
+func chiHandleWriteWildcard(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, chi.URLParam(r, "*"))
+}
+
+func chiHandleParams(path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeParams(w, path, func(name string, catchAll bool) string {
+			if catchAll {
+				return chi.URLParam(r, "*")
+			}
+			return chi.URLParam(r, name)
+		})
+	}
+}
+
+func loadChi(routes []route) http.Handler {
+	h := httpHandlerFunc
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+
+	mux := chi.NewRouter()
+	mw := standardMiddleware()
+	for range loadMiddlewares {
+		mux.Use(mw)
+	}
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = chiHandleParams(route.path)
+		}
+
+		path := paramRe.ReplaceAllString(route.path, "{$1}")
+		path = wildcardRe.ReplaceAllString(path, "*")
+
+		switch route.method {
+		case http.MethodGet:
+			mux.Get(path, h)
+		case http.MethodPost:
+			mux.Post(path, h)
+		case http.MethodPut:
+			mux.Put(path, h)
+		case http.MethodPatch:
+			mux.Patch(path, h)
+		case http.MethodDelete:
+			mux.Delete(path, h)
+		default:
+			panic("Unknown HTTP method: " + route.method)
+		}
+	}
+	return mux
+}
+
+func loadChiSingle(method, path string, handler http.HandlerFunc) http.Handler {
+	mux := chi.NewRouter()
+	switch method {
+	case http.MethodGet:
+		mux.Get(path, handler)
+	case http.MethodPost:
+		mux.Post(path, handler)
+	case http.MethodPut:
+		mux.Put(path, handler)
+	case http.MethodPatch:
+		mux.Patch(path, handler)
+	case http.MethodDelete:
+		mux.Delete(path, handler)
+	default:
+		panic("Unknown HTTP method: " + method)
+	}
+	return mux
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"text/tabwriter"
+)
+
+// confidence is the confidence level of the intervals of the medians.
+const confidence = 0.95
+
+// comparison compares the ns/op samples of one benchmark of one router in
+// two result sets.
+type comparison struct {
+	benchmark string
+	api       string
+	router    string
+
+	old, new samples
+
+	// delta is the relative change of the median, p the p-value of the
+	// Mann-Whitney U-test
+	delta float64
+	p     float64
+}
+
+// samples are the ns/op of the runs of a benchmark.
+type samples []float64
+
+// String formats the median and the relative confidence interval.
+func (s samples) String() string {
+	if len(s) == 0 {
+		return "-"
+	}
+	m := median(s)
+	lo, hi := medianCI(s, confidence)
+	if math.IsInf(lo, 0) {
+		return formatValue("%s", m) + " ± ∞"
+	}
+	return fmt.Sprintf("%s ± %.0f%%", formatValue("%s", m), math.Max(m-lo, hi-m)/m*100)
+}
+
+// compareResults compares the benchmarks found in both result sets, in the
+// order of the new ones.
+func compareResults(before, after *resultSet) []comparison {
+	type key struct{ benchmark, router string }
+	collect := func(rs *resultSet) (map[key]samples, []key) {
+		m := make(map[key]samples)
+		var keys []key
+		for _, r := range rs.Results {
+			k := key{r.name(), r.Router}
+			if _, ok := m[k]; !ok {
+				keys = append(keys, k)
+			}
+			m[k] = append(m[k], r.NsPerOp)
+		}
+		return m, keys
+	}
+	oldSamples, _ := collect(before)
+	newSamples, keys := collect(after)
+
+	apiNames := make(map[string]string)
+	for _, r := range after.Results {
+		apiNames[r.name()] = r.API
+	}
+
+	var cs []comparison
+	for _, k := range keys {
+		o, ok := oldSamples[k]
+		if !ok {
+			continue
+		}
+		n := newSamples[k]
+		cs = append(cs, comparison{
+			benchmark: k.benchmark,
+			api:       apiNames[k.benchmark],
+			router:    k.router,
+			old:       o,
+			new:       n,
+			delta:     median(n)/median(o) - 1,
+			p:         mannWhitneyU(o, n),
+		})
+	}
+	return cs
+}
+
+// significant reports whether the change is significant at level alpha.
+func (c comparison) significant(alpha float64) bool {
+	return c.p < alpha
+}
+
+// regression reports whether the benchmark got significantly slower by more
+// than threshold, given as a fraction.
+func (c comparison) regression(alpha, threshold float64) bool {
+	return c.significant(alpha) && c.delta > threshold
+}
+
+// writeComparison writes the comparisons grouped by API, like benchstat does,
+// and returns the number of regressions. Changes that are not significant at
+// level alpha are shown as "~".
+func writeComparison(w io.Writer, cs []comparison, alpha, threshold float64) (int, error) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+
+	var groups []string
+	byAPI := make(map[string][]comparison)
+	for _, c := range cs {
+		if _, ok := byAPI[c.api]; !ok {
+			groups = append(groups, c.api)
+		}
+		byAPI[c.api] = append(byAPI[c.api], c)
+	}
+
+	regressions := make(map[string]int)
+	var routers []string
+	fewSamples := false
+	for i, api := range groups {
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+		title := api
+		if title == "" {
+			title = "Micro"
+		}
+		fmt.Fprintf(tw, "%s\told ns/op\tnew ns/op\tdelta\n", title)
+		for _, c := range byAPI[api] {
+			delta := "~"
+			if c.significant(alpha) {
+				delta = fmt.Sprintf("%+.2f%%", c.delta*100)
+			}
+			note := ""
+			if c.regression(alpha, threshold) {
+				note = " REGRESSION"
+				if regressions[c.router] == 0 {
+					routers = append(routers, c.router)
+				}
+				regressions[c.router]++
+			}
+			fmt.Fprintf(tw, "%s/%s\t%s\t%s\t%s\t(p=%.3f n=%d+%d)%s\n",
+				c.benchmark, c.router, c.old, c.new, delta, c.p, len(c.old), len(c.new), note)
+			if len(c.old) < 5 || len(c.new) < 5 {
+				fewSamples = true
+			}
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return 0, err
+	}
+
+	if fewSamples {
+		fmt.Fprintln(w, "\nNote: some benchmarks have less than 5 samples, run them with -count=10 for significant results.")
+	}
+	total := 0
+	if len(routers) > 0 {
+		fmt.Fprintf(w, "\nRegressions of more than %.1f%%:\n", threshold*100)
+		for _, router := range routers {
+			fmt.Fprintf(w, "  %s: %d\n", router, regressions[router])
+			total += regressions[router]
+		}
+	}
+	return total, nil
+}
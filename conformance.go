@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"text/tabwriter"
+)
+
+// responseCheck counts the responses of a router to requests it should
+// reject.
+type responseCheck struct {
+	requests int
+	status   map[int]int
+	panics   int
+
+	// allow counts the 405 responses with a valid Allow header
+	allow int
+
+	// unexpected describes the first response with a status other than
+	// 404 or 405, or a panic
+	unexpected string
+}
+
+// checkResponses loads the API into the router and sends it the requests.
+func checkResponses(a Adapter, api api, requests []route) responseCheck {
+	c := responseCheck{status: make(map[int]int)}
+	h := a.Load(api.routes)
+	for _, r := range requests {
+		c.requests++
+		req, _ := http.NewRequest(r.method, r.path, nil)
+		req.RequestURI = r.path
+		w := httptest.NewRecorder()
+		if !serveRecover(h, w, req) {
+			c.panics++
+			if c.unexpected == "" {
+				c.unexpected = fmt.Sprintf("panic for %s %s", r.method, r.path)
+			}
+			continue
+		}
+		c.status[w.Code]++
+		switch w.Code {
+		case http.StatusNotFound:
+		case http.StatusMethodNotAllowed:
+			allow := strings.Join(w.Header().Values("Allow"), ",")
+			if validAllow(allow, allowedMethods(api.routes, r.path)) {
+				c.allow++
+			}
+		default:
+			if c.unexpected == "" {
+				c.unexpected = fmt.Sprintf("%d for %s %s", w.Code, r.method, r.path)
+			}
+		}
+	}
+	return c
+}
+
+// add adds the counts of o to c.
+func (c *responseCheck) add(o responseCheck) {
+	c.requests += o.requests
+	c.panics += o.panics
+	c.allow += o.allow
+	if c.status == nil {
+		c.status = make(map[int]int)
+	}
+	for code, n := range o.status {
+		c.status[code] += n
+	}
+	if c.unexpected == "" {
+		c.unexpected = o.unexpected
+	}
+}
+
+// serveRecover serves the request and reports whether the handler returned
+// without panicking.
+func serveRecover(h http.Handler, w http.ResponseWriter, r *http.Request) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	h.ServeHTTP(w, r)
+	return true
+}
+
+// share formats n of total as "yes", "no" or a percentage.
+func share(n, total int) string {
+	switch {
+	case total == 0:
+		return "-"
+	case n == total:
+		return "yes"
+	case n == 0:
+		return "no"
+	}
+	return fmt.Sprintf("%d%%", n*100/total)
+}
+
+// writeFeatures checks each router with the requests of the GitHub, Google+
+// and Parse APIs no route matches and writes the feature matrix: whether it
+// supports parameters, catch-all parameters and static segments and
+// parameters at the same position, has a middleware API, builds the paths of
+// named routes, can add routes while serving requests, responds with 404 Not
+// Found to unknown paths, with 405 Method Not Allowed to known paths with a
+// wrong method and sets a valid Allow header.
+func writeFeatures(w io.Writer, routers []Adapter) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "Router\tParams\tWildcard\tMixed\tMiddleware\tReverse\tAdd\t404\t405\tAllow\tUnexpected")
+	for _, a := range routers {
+		var notFound, wrongMethod responseCheck
+		for _, name := range []string{"GitHub", "GPlus", "Parse", "Static"} {
+			api := findAPI(name)
+			if !canServe(a, api.routes) {
+				continue
+			}
+			notFound.add(checkResponses(a, api, notFoundRoutes(api.routes)))
+			wrongMethod.add(checkResponses(a, api, methodNotAllowedRoutes(api.routes)))
+		}
+
+		params, wildcard, mixed, middleware, reverse := "no", "no", "no", "no", "no"
+		if a.Features().Has(FeatureParams) {
+			params = "yes"
+		}
+		if a.Features().Has(FeatureWildcard) {
+			wildcard = "yes"
+		}
+		if a.Features().Has(FeatureMixedSegments) {
+			mixed = "yes"
+		}
+		if a.Features().Has(FeatureMiddleware) {
+			middleware = "yes"
+		}
+		if _, ok := a.(ReverseAdapter); ok {
+			reverse = "yes"
+		}
+		unexpected := notFound.unexpected
+		if unexpected == "" {
+			unexpected = wrongMethod.unexpected
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s", a.Name(), params, wildcard, mixed, middleware, reverse, addSafety(a),
+			share(notFound.status[http.StatusNotFound], notFound.requests),
+			share(wrongMethod.status[http.StatusMethodNotAllowed], wrongMethod.requests),
+			share(wrongMethod.allow, wrongMethod.requests),
+		)
+		if unexpected != "" {
+			fmt.Fprintf(tw, "\t%s", unexpected)
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,92 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/naoina/denco"
+)
+
+func init() {
+	register(dencoAdapter{})
+}
+
+type dencoAdapter struct{}
+
+func (dencoAdapter) Name() string { return "Denco" }
+func (dencoAdapter) Features() Feature {
+	return FeatureParams | FeatureWildcard | FeatureMixedSegments
+}
+
+func (dencoAdapter) Unsupported(f Feature) string {
+	switch f {
+	case FeatureAdd:
+		return "routes are compiled by Build"
+	}
+	return ""
+}
+
+func (dencoAdapter) Load(routes []route) http.Handler {
+	return loadDenco(routes)
+}
+
+func (dencoAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := dencoHandler
+	if write {
+		h = dencoHandlerWrite
+	}
+	return loadDencoSingle(method, path, h)
+}
+
+func dencoHandler(w http.ResponseWriter, r *http.Request, params denco.Params) {}
+
+func dencoHandlerWrite(w http.ResponseWriter, r *http.Request, params denco.Params) {
+	io.WriteString(w, params.Get("name"))
+}
+
+func dencoHandlerTest(w http.ResponseWriter, r *http.Request, params denco.Params) {
+	io.WriteString(w, r.RequestURI)
+}
+
+func dencoHandlerParams(path string) denco.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request, params denco.Params) {
+		writeParams(w, path, func(name string, _ bool) string {
+			return params.Get(name)
+		})
+	}
+}
+
+func loadDenco(routes []route) http.Handler {
+	h := dencoHandler
+	if loadTestHandler {
+		h = dencoHandlerTest
+	}
+
+	mux := denco.NewMux()
+	handlers := make([]denco.Handler, 0, len(routes))
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = dencoHandlerParams(route.path)
+		}
+		handler := mux.Handler(route.method, route.path, h)
+		handlers = append(handlers, handler)
+	}
+	handler, err := mux.Build(handlers)
+	if err != nil {
+		panic(err)
+	}
+	return handler
+}
+
+func loadDencoSingle(method, path string, h denco.HandlerFunc) http.Handler {
+	mux := denco.NewMux()
+	handler, err := mux.Build([]denco.Handler{mux.Handler(method, path, h)})
+	if err != nil {
+		panic(err)
+	}
+	return handler
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/HdrHistogram/hdrhistogram-go"
+)
+
+// e2eConns is the number of keep-alive connections the load generator of the
+// end-to-end benchmarks sends requests on.
+var e2eConns = 8
+
+// e2eBenchmarks are the names of the benchmarks added by addE2EBenchmarks.
+var e2eBenchmarks []string
+
+// addE2EBenchmarks adds an end-to-end benchmark for each API, named after the
+// API, e.g. GithubE2E.
+func addE2EBenchmarks() {
+	for _, api := range apis {
+		name := benchPrefix(api.name) + "E2E"
+		benchmarks = append(benchmarks, e2eBenchmark(name, api.name))
+		e2eBenchmarks = append(e2eBenchmarks, name)
+	}
+}
+
+// e2eBenchmark serves the router with all routes of the API on a loopback
+// HTTP server and requests all routes in turn, one per operation, see
+// benchE2E. It runs at each GOMAXPROCS level like the parallel benchmarks,
+// since the server and the load generator share the CPUs.
+func e2eBenchmark(name, apiName string) benchmark {
+	return benchmark{
+		name:     name,
+		api:      apiName,
+		parallel: true,
+		supports: func(a Adapter) bool {
+			return canServe(a, findAPI(apiName).routes)
+		},
+		run: func(b *testing.B, a Adapter) {
+			api := findAPI(apiName)
+			benchE2E(b, apiHandler(api, a), requests(api.routes))
+		},
+	}
+}
+
+// benchE2E serves the router with httptest.NewServer and sends the requests
+// to it over e2eConns keep-alive connections, each waiting for the response
+// before sending the next request. It reports like runLoad.
+func benchE2E(b *testing.B, router http.Handler, reqs []route) {
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	host := srv.Listener.Addr().String()
+	raw := make([][]byte, len(reqs))
+	for i, r := range reqs {
+		raw[i] = fmt.Appendf(nil, "%s %s HTTP/1.1\r\nHost: %s\r\nContent-Length: 0\r\n\r\n", r.method, r.path, host)
+	}
+
+	type conn struct {
+		*bufio.Reader
+		*bufio.Writer
+	}
+	conns := make([]conn, e2eConns)
+	for i := range conns {
+		c, err := net.Dial("tcp", host)
+		if err != nil {
+			b.Fatal(err)
+		}
+		defer c.Close()
+		conns[i] = conn{bufio.NewReader(c), bufio.NewWriter(c)}
+	}
+
+	runLoad(b, reqs, len(conns), func(worker, i int) (int, error) {
+		c := conns[worker]
+		c.Write(raw[i])
+		if err := c.Flush(); err != nil {
+			return 0, err
+		}
+		resp, err := http.ReadResponse(c.Reader, nil)
+		if err != nil {
+			return 0, err
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	})
+}
+
+// runLoad sends the requests in turn from the workers, calling send with the
+// worker and the index of the request, until b.N requests are done. send
+// returns the status code of the response, which has to be 200 OK. Besides
+// the time per request, it reports the throughput as the req/s metric and
+// the percentiles of the latency like benchLatency. The allocations include
+// those of the server and of the load generator.
+func runLoad(b *testing.B, reqs []route, workers int, send func(worker, i int) (int, error)) {
+	hists := make([]*hdrhistogram.Histogram, workers)
+	for i := range hists {
+		hists[i] = newLatencyHistogram()
+	}
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failure error
+	fail := func(err error) {
+		mu.Lock()
+		if failure == nil {
+			failure = err
+		}
+		mu.Unlock()
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for worker := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				n := int(next.Add(1) - 1)
+				if n >= b.N {
+					return
+				}
+				i := n % len(reqs)
+				start := time.Now()
+				status, err := send(worker, i)
+				if err != nil {
+					fail(err)
+					return
+				}
+				hists[worker].RecordValue(int64(min(time.Since(start), maxLatency)))
+				if status != http.StatusOK {
+					fail(fmt.Errorf("%d for %s %s", status, reqs[i].method, reqs[i].path))
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	b.StopTimer()
+	if failure != nil {
+		b.Fatal(failure)
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "req/s")
+	for _, h := range hists[1:] {
+		hists[0].Merge(h)
+	}
+	reportLatency(b, hists[0])
+}
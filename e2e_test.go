@@ -0,0 +1,65 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRoutersE2E checks that the routers route the requests of the APIs when
+// served by a real HTTP server.
+func TestRoutersE2E(t *testing.T) {
+	loadTestHandler = true
+	defer func() { loadTestHandler = false }()
+
+	for _, router := range adapters {
+		t.Run(router.Name(), func(t *testing.T) {
+			for _, name := range []string{"GitHub", "Static"} {
+				api := findAPI(name)
+				if !canServe(router, api.routes) {
+					continue
+				}
+				srv := httptest.NewServer(router.Load(api.routes))
+				defer srv.Close()
+
+				for _, route := range requests(api.routes) {
+					req, _ := http.NewRequest(route.method, srv.URL+route.path, nil)
+					resp, err := srv.Client().Do(req)
+					if err != nil {
+						t.Fatal(err)
+					}
+					body, _ := io.ReadAll(resp.Body)
+					resp.Body.Close()
+					if resp.StatusCode != 200 || string(body) != route.path {
+						t.Errorf("API %s: %d - %s; expected %s %s", api.name, resp.StatusCode, body, route.method, route.path)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBenchE2E(t *testing.T) {
+	h := httpRouterAdapter{}.Load(githubAPI)
+	r := testing.Benchmark(func(b *testing.B) {
+		benchE2E(b, h, requests(githubAPI))
+	})
+	if r.N == 0 {
+		t.Fatal("the benchmark failed")
+	}
+	for _, unit := range []string{"req/s", "p50-ns", "p99.9-ns"} {
+		if r.Extra[unit] <= 0 {
+			t.Errorf("got %v %s, expected more than 0", r.Extra[unit], unit)
+		}
+	}
+}
+
+// All routes of each API served on a loopback HTTP server, added with -e2e
+func BenchmarkE2E(b *testing.B) {
+	for _, name := range e2eBenchmarks {
+		b.Run(name, func(b *testing.B) {
+			runBenchmark(b, name)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func init() {
+	register(echoAdapter{})
+}
+
+type echoAdapter struct{}
+
+func (echoAdapter) Name() string { return "Echo" }
+func (echoAdapter) Features() Feature {
+	return FeatureParams | FeatureMethodNotAllowed | FeatureWildcard | FeatureMixedSegments |
+		FeatureMiddleware | FeatureAdd | FeatureReverse
+}
+
+func (echoAdapter) Deviation() deviation {
+	return deviation{notFound: "matches routes ending in a parameter with extra segments"}
+}
+
+func (echoAdapter) Load(routes []route) http.Handler {
+	return loadEcho(routes)
+}
+
+func (echoAdapter) Reverse(router http.Handler) reverseFunc {
+	e := router.(*echo.Echo)
+	return func(name string, pairs []string) string {
+		values := make([]interface{}, 0, len(pairs)/2)
+		for i := 1; i < len(pairs); i += 2 {
+			values = append(values, pairs[i])
+		}
+		return e.Reverse(name, values...)
+	}
+}
+
+func (echoAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	var h echo.HandlerFunc = echoHandler
+	if write {
+		h = echoHandlerWrite
+		if wildcardRe.MatchString(path) {
+			h = echoHandlerWriteWildcard
+		}
+	}
+	path = wildcardRe.ReplaceAllString(path, "*")
+	return loadEchoSingle(method, path, h)
+}
+
+func (echoAdapter) Add(router http.Handler, r route) {
+	var h echo.HandlerFunc = echoHandler
+	if loadTestHandler {
+		h = echoHandlerTest
+	}
+	router.(*echo.Echo).Add(r.method, wildcardRe.ReplaceAllString(r.path, "*"), h)
+}
+
+func echoHandler(c echo.Context) error {
+	return nil
+}
+
+func echoHandlerWrite(c echo.Context) error {
+	io.WriteString(c.Response(), c.Param("name"))
+	return nil
+}
+
+func echoHandlerWriteWildcard(c echo.Context) error {
+	io.WriteString(c.Response(), c.Param("*"))
+	return nil
+}
+
+func echoHandlerTest(c echo.Context) error {
+	io.WriteString(c.Response(), c.Request().RequestURI)
+	return nil
+}
+
+func echoHandlerParams(path string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		writeParams(c.Response(), path, func(name string, catchAll bool) string {
+			if catchAll {
+				return c.Param("*")
+			}
+			return c.Param(name)
+		})
+		return nil
+	}
+}
+
+func echoMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return next(c)
+	}
+}
+
+func echoMiddlewareTest(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		middlewareCalls.Add(1)
+		return next(c)
+	}
+}
+
+func loadEcho(routes []route) http.Handler {
+	var h echo.HandlerFunc = echoHandler
+	if loadTestHandler {
+		h = echoHandlerTest
+	}
+
+	e := echo.New()
+	mw := echoMiddleware
+	if loadTestHandler {
+		mw = echoMiddlewareTest
+	}
+	for range loadMiddlewares {
+		e.Use(mw)
+	}
+	for i, r := range routes {
+		if loadParamsHandler {
+			h = echoHandlerParams(r.path)
+		}
+
+		path := wildcardRe.ReplaceAllString(r.path, "*")
+
+		var rt *echo.Route
+		switch r.method {
+		case http.MethodGet:
+			rt = e.GET(path, h)
+		case http.MethodPost:
+			rt = e.POST(path, h)
+		case http.MethodPut:
+			rt = e.PUT(path, h)
+		case http.MethodPatch:
+			rt = e.PATCH(path, h)
+		case http.MethodDelete:
+			rt = e.DELETE(path, h)
+		default:
+			panic("Unknow HTTP method: " + r.method)
+		}
+		if loadRouteNames {
+			rt.Name = routeName(i)
+		}
+	}
+	return e
+}
+
+func loadEchoSingle(method, path string, h echo.HandlerFunc) http.Handler {
+	e := echo.New()
+	switch method {
+	case http.MethodGet:
+		e.GET(path, h)
+	case http.MethodPost:
+		e.POST(path, h)
+	case http.MethodPut:
+		e.PUT(path, h)
+	case http.MethodPatch:
+		e.PATCH(path, h)
+	case http.MethodDelete:
+		e.DELETE(path, h)
+	default:
+		panic("Unknow HTTP method: " + method)
+	}
+	return e
+}
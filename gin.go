@@ -0,0 +1,104 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	initGin()
+	register(ginAdapter{})
+}
+
+type ginAdapter struct{}
+
+func (ginAdapter) Name() string { return "Gin" }
+func (ginAdapter) Features() Feature {
+	return FeatureParams | FeatureWildcard | FeatureMixedSegments | FeatureMiddleware | FeatureAdd
+}
+
+func (ginAdapter) Load(routes []route) http.Handler {
+	return loadGin(routes)
+}
+
+func (ginAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := ginHandle
+	if write {
+		h = ginHandleWrite
+	}
+	return loadGinSingle(method, path, h)
+}
+
+func (ginAdapter) Add(router http.Handler, r route) {
+	h := ginHandle
+	if loadTestHandler {
+		h = ginHandleTest
+	}
+	router.(*gin.Engine).Handle(r.method, r.path, h)
+}
+
+func ginHandle(_ *gin.Context) {}
+
+func ginHandleWrite(c *gin.Context) {
+	io.WriteString(c.Writer, c.Params.ByName("name"))
+}
+
+func ginHandleTest(c *gin.Context) {
+	io.WriteString(c.Writer, c.Request.RequestURI)
+}
+
+func initGin() {
+	gin.SetMode(gin.ReleaseMode)
+}
+
+func ginHandleParams(path string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writeParams(c.Writer, path, func(name string, _ bool) string {
+			return c.Params.ByName(name)
+		})
+	}
+}
+
+func ginMiddleware(c *gin.Context) {
+	c.Next()
+}
+
+func ginMiddlewareTest(c *gin.Context) {
+	middlewareCalls.Add(1)
+	c.Next()
+}
+
+func loadGin(routes []route) http.Handler {
+	h := ginHandle
+	if loadTestHandler {
+		h = ginHandleTest
+	}
+
+	router := gin.New()
+	mw := ginMiddleware
+	if loadTestHandler {
+		mw = ginMiddlewareTest
+	}
+	for range loadMiddlewares {
+		router.Use(mw)
+	}
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = ginHandleParams(route.path)
+		}
+		router.Handle(route.method, route.path, h)
+	}
+	return router
+}
+
+func loadGinSingle(method, path string, handle gin.HandlerFunc) http.Handler {
+	router := gin.New()
+	router.Handle(method, path, handle)
+	return router
+}
@@ -3,6 +3,7 @@ module github.com/wayneashleyberry/go-http-routing-benchmark
 go 1.24.5
 
 require (
+	github.com/HdrHistogram/hdrhistogram-go v1.1.2
 	github.com/ant0ine/go-json-rest v3.3.2+incompatible
 	github.com/astaxie/beego v1.12.3
 	github.com/bmizerany/pat v0.0.0-20210406213842-e4b6760bdd6f
@@ -31,6 +32,7 @@ require (
 	github.com/zenazn/goji v1.0.1
 	goji.io v2.0.2+incompatible
 	gopkg.in/macaron.v1 v1.5.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -54,7 +56,6 @@ require (
 	github.com/hashicorp/golang-lru v1.0.2 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
 	github.com/klauspost/cpuid/v2 v2.3.0 // indirect
-	github.com/kr/text v0.2.0 // indirect
 	github.com/labstack/gommon v0.4.2 // indirect
 	github.com/leodido/go-urn v1.4.0 // indirect
 	github.com/mattn/go-colorable v0.1.14 // indirect
@@ -92,5 +93,4 @@ require (
 	gopkg.in/go-playground/assert.v1 v1.2.1 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,127 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gocraft/web"
+)
+
+func init() {
+	register(gocraftWebAdapter{})
+}
+
+type gocraftWebAdapter struct{}
+
+func (gocraftWebAdapter) Name() string { return "GocraftWeb" }
+func (gocraftWebAdapter) Features() Feature {
+	return FeatureParams | FeatureMixedSegments | FeatureMiddleware | FeatureAdd
+}
+
+func (gocraftWebAdapter) Unsupported(f Feature) string {
+	switch f {
+	case FeatureWildcard:
+		return "parameters can't match more than one segment"
+	}
+	return ""
+}
+
+func (gocraftWebAdapter) Load(routes []route) http.Handler {
+	return loadGocraftWeb(routes)
+}
+
+func (gocraftWebAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	var h interface{} = gocraftWebHandler
+	if write {
+		h = gocraftWebHandlerWrite
+	}
+	return loadGocraftWebSingle(method, path, h)
+}
+
+func (gocraftWebAdapter) Add(router http.Handler, r route) {
+	var h interface{} = gocraftWebHandler
+	if loadTestHandler {
+		h = gocraftWebHandlerTest
+	}
+	gocraftWebHandle(router.(*web.Router), r.method, r.path, h)
+}
+
+type gocraftWebContext struct{}
+
+func gocraftWebHandler(w web.ResponseWriter, r *web.Request) {}
+
+func gocraftWebHandlerWrite(w web.ResponseWriter, r *web.Request) {
+	io.WriteString(w, r.PathParams["name"])
+}
+
+func gocraftWebHandlerTest(w web.ResponseWriter, r *web.Request) {
+	io.WriteString(w, r.RequestURI)
+}
+
+func gocraftWebHandlerParams(path string) func(web.ResponseWriter, *web.Request) {
+	return func(w web.ResponseWriter, r *web.Request) {
+		writeParams(w, path, func(name string, _ bool) string {
+			return r.PathParams[name]
+		})
+	}
+}
+
+func gocraftWebMiddleware(w web.ResponseWriter, r *web.Request, next web.NextMiddlewareFunc) {
+	next(w, r)
+}
+
+func gocraftWebMiddlewareTest(w web.ResponseWriter, r *web.Request, next web.NextMiddlewareFunc) {
+	middlewareCalls.Add(1)
+	next(w, r)
+}
+
+func loadGocraftWeb(routes []route) http.Handler {
+	h := gocraftWebHandler
+	if loadTestHandler {
+		h = gocraftWebHandlerTest
+	}
+
+	router := web.New(gocraftWebContext{})
+	mw := gocraftWebMiddleware
+	if loadTestHandler {
+		mw = gocraftWebMiddlewareTest
+	}
+	for range loadMiddlewares {
+		router.Middleware(mw)
+	}
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = gocraftWebHandlerParams(route.path)
+		}
+
+		gocraftWebHandle(router, route.method, route.path, h)
+	}
+	return router
+}
+
+func loadGocraftWebSingle(method, path string, handler interface{}) http.Handler {
+	router := web.New(gocraftWebContext{})
+	gocraftWebHandle(router, method, path, handler)
+	return router
+}
+
+func gocraftWebHandle(router *web.Router, method, path string, h interface{}) {
+	switch method {
+	case http.MethodGet:
+		router.Get(path, h)
+	case http.MethodPost:
+		router.Post(path, h)
+	case http.MethodPut:
+		router.Put(path, h)
+	case http.MethodPatch:
+		router.Patch(path, h)
+	case http.MethodDelete:
+		router.Delete(path, h)
+	default:
+		panic("Unknown HTTP method: " + method)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	goji "github.com/zenazn/goji/web"
+)
+
+func init() {
+	register(gojiAdapter{})
+}
+
+type gojiAdapter struct{}
+
+func (gojiAdapter) Name() string { return "Goji" }
+func (gojiAdapter) Features() Feature {
+	return FeatureParams | FeatureWildcard | FeatureMixedSegments |
+		FeatureMiddleware | FeatureAdd | FeatureConcurrentAdd
+}
+
+func (gojiAdapter) Load(routes []route) http.Handler {
+	return loadGoji(routes)
+}
+
+func (gojiAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	var h interface{} = httpHandlerFunc
+	if write {
+		h = gojiFuncWrite
+		if wildcardRe.MatchString(path) {
+			h = gojiFuncWriteWildcard
+		}
+	}
+	path = wildcardRe.ReplaceAllString(path, "*")
+	return loadGojiSingle(method, path, h)
+}
+
+func (gojiAdapter) Add(router http.Handler, r route) {
+	var h interface{} = httpHandlerFunc
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+	gojiHandle(router.(*goji.Mux), r.method, wildcardRe.ReplaceAllString(r.path, "*"), h)
+}
+
+func gojiFuncWrite(c goji.C, w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, c.URLParams["name"])
+}
+
+func gojiFuncWriteWildcard(c goji.C, w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, c.URLParams["*"])
+}
+
+func gojiFuncParams(path string) func(goji.C, http.ResponseWriter, *http.Request) {
+	return func(c goji.C, w http.ResponseWriter, r *http.Request) {
+		writeParams(w, path, func(name string, catchAll bool) string {
+			if catchAll {
+				return c.URLParams["*"]
+			}
+			return c.URLParams[name]
+		})
+	}
+}
+
+func loadGoji(routes []route) http.Handler {
+	var h interface{} = httpHandlerFunc
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+
+	mux := goji.New()
+	mw := standardMiddleware()
+	for range loadMiddlewares {
+		mux.Use(mw)
+	}
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = gojiFuncParams(route.path)
+		}
+
+		path := wildcardRe.ReplaceAllString(route.path, "*")
+
+		gojiHandle(mux, route.method, path, h)
+	}
+	return mux
+}
+
+func loadGojiSingle(method, path string, handler interface{}) http.Handler {
+	mux := goji.New()
+	gojiHandle(mux, method, path, handler)
+	return mux
+}
+
+func gojiHandle(mux *goji.Mux, method, path string, h interface{}) {
+	switch method {
+	case http.MethodGet:
+		mux.Get(path, h)
+	case http.MethodPost:
+		mux.Post(path, h)
+	case http.MethodPut:
+		mux.Put(path, h)
+	case http.MethodPatch:
+		mux.Patch(path, h)
+	case http.MethodDelete:
+		mux.Delete(path, h)
+	default:
+		panic("Unknown HTTP method: " + method)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	gojiv2 "goji.io"
+	gojiv2pat "goji.io/pat"
+)
+
+func init() {
+	register(gojiv2Adapter{})
+}
+
+type gojiv2Adapter struct{}
+
+func (gojiv2Adapter) Name() string { return "Gojiv2" }
+func (gojiv2Adapter) Features() Feature {
+	return FeatureParams | FeatureMixedSegments | FeatureMiddleware | FeatureAdd
+}
+
+func (gojiv2Adapter) Unsupported(f Feature) string {
+	switch f {
+	case FeatureWildcard:
+		return "the remainder matched by /* is not available with the other parameters"
+	}
+	return ""
+}
+
+func (gojiv2Adapter) Load(routes []route) http.Handler {
+	return loadGojiv2(routes)
+}
+
+func (gojiv2Adapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := gojiv2Handler
+	if write {
+		h = gojiv2HandlerWrite
+	}
+	return loadGojiv2Single(method, path, h)
+}
+
+func (gojiv2Adapter) Add(router http.Handler, r route) {
+	h := gojiv2Handler
+	if loadTestHandler {
+		h = gojiv2HandlerTest
+	}
+	router.(*gojiv2.Mux).HandleFunc(gojiv2Pattern(r.method, r.path), h)
+}
+
+func gojiv2Handler(w http.ResponseWriter, r *http.Request) {}
+
+func gojiv2HandlerWrite(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, gojiv2pat.Param(r, "name"))
+}
+
+func gojiv2HandlerTest(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, r.RequestURI)
+}
+
+func gojiv2HandlerParams(path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeParams(w, path, func(name string, _ bool) string {
+			return gojiv2pat.Param(r, name)
+		})
+	}
+}
+
+func loadGojiv2(routes []route) http.Handler {
+	h := gojiv2Handler
+	if loadTestHandler {
+		h = gojiv2HandlerTest
+	}
+
+	mux := gojiv2.NewMux()
+	mw := standardMiddleware()
+	for range loadMiddlewares {
+		mux.Use(mw)
+	}
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = gojiv2HandlerParams(route.path)
+		}
+
+		mux.HandleFunc(gojiv2Pattern(route.method, route.path), h)
+	}
+	return mux
+}
+
+func loadGojiv2Single(method, path string, handler func(http.ResponseWriter, *http.Request)) http.Handler {
+	mux := gojiv2.NewMux()
+	mux.HandleFunc(gojiv2Pattern(method, path), handler)
+	return mux
+}
+
+func gojiv2Pattern(method, path string) *gojiv2pat.Pattern {
+	switch method {
+	case http.MethodGet:
+		return gojiv2pat.Get(path)
+	case http.MethodPost:
+		return gojiv2pat.Post(path)
+	case http.MethodPut:
+		return gojiv2pat.Put(path)
+	case http.MethodPatch:
+		return gojiv2pat.Patch(path)
+	case http.MethodDelete:
+		return gojiv2pat.Delete(path)
+	default:
+		panic("Unknown HTTP method: " + method)
+	}
+}
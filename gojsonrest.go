@@ -0,0 +1,118 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/ant0ine/go-json-rest/rest"
+)
+
+func init() {
+	register(goJsonRestAdapter{})
+}
+
+type goJsonRestAdapter struct{}
+
+func (goJsonRestAdapter) Name() string { return "GoJsonRest" }
+func (goJsonRestAdapter) Features() Feature {
+	return FeatureParams | FeatureWildcard | FeatureMixedSegments | FeatureMiddleware
+}
+
+func (goJsonRestAdapter) Unsupported(f Feature) string {
+	switch f {
+	case FeatureAdd:
+		return "routes are compiled by MakeRouter"
+	}
+	return ""
+}
+
+func (goJsonRestAdapter) Load(routes []route) http.Handler {
+	return loadGoJsonRest(routes)
+}
+
+func (goJsonRestAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := goJsonRestHandler
+	if write {
+		h = goJsonRestHandlerWrite
+	}
+	return loadGoJsonRestSingle(method, path, h)
+}
+
+func goJsonRestHandler(w rest.ResponseWriter, req *rest.Request) {}
+
+func goJsonRestHandlerWrite(w rest.ResponseWriter, req *rest.Request) {
+	io.WriteString(w.(io.Writer), req.PathParam("name"))
+}
+
+func goJsonRestHandlerTest(w rest.ResponseWriter, req *rest.Request) {
+	io.WriteString(w.(io.Writer), req.RequestURI)
+}
+
+func goJsonRestHandlerParams(path string) rest.HandlerFunc {
+	return func(w rest.ResponseWriter, req *rest.Request) {
+		writeParams(w.(io.Writer), path, func(name string, _ bool) string {
+			return req.PathParam(name)
+		})
+	}
+}
+
+func goJsonRestMiddleware(next rest.HandlerFunc) rest.HandlerFunc {
+	return func(w rest.ResponseWriter, r *rest.Request) {
+		next(w, r)
+	}
+}
+
+func goJsonRestMiddlewareTest(next rest.HandlerFunc) rest.HandlerFunc {
+	return func(w rest.ResponseWriter, r *rest.Request) {
+		middlewareCalls.Add(1)
+		next(w, r)
+	}
+}
+
+func loadGoJsonRest(routes []route) http.Handler {
+	h := goJsonRestHandler
+	if loadTestHandler {
+		h = goJsonRestHandlerTest
+	}
+
+	api := rest.NewApi()
+	mw := goJsonRestMiddleware
+	if loadTestHandler {
+		mw = goJsonRestMiddlewareTest
+	}
+	for range loadMiddlewares {
+		api.Use(rest.MiddlewareSimple(mw))
+	}
+	restRoutes := make([]*rest.Route, 0, len(routes))
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = goJsonRestHandlerParams(route.path)
+		}
+		restRoutes = append(restRoutes,
+			&rest.Route{HttpMethod: route.method, PathExp: route.path, Func: h},
+		)
+	}
+	router, err := rest.MakeRouter(restRoutes...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	api.SetApp(router)
+	return api.MakeHandler()
+}
+
+func loadGoJsonRestSingle(method, path string, hfunc rest.HandlerFunc) http.Handler {
+	api := rest.NewApi()
+	router, err := rest.MakeRouter(
+		&rest.Route{HttpMethod: method, PathExp: path, Func: hfunc},
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	api.SetApp(router)
+	return api.MakeHandler()
+}
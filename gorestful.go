@@ -0,0 +1,140 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/emicklei/go-restful"
+)
+
+func init() {
+	register(goRestfulAdapter{})
+}
+
+type goRestfulAdapter struct{}
+
+func (goRestfulAdapter) Name() string { return "GoRestful" }
+func (goRestfulAdapter) Features() Feature {
+	return FeatureParams | FeatureMethodNotAllowed | FeatureWildcard | FeatureMixedSegments |
+		FeatureMiddleware | FeatureAdd
+}
+
+func (goRestfulAdapter) Load(routes []route) http.Handler {
+	return loadGoRestful(routes)
+}
+
+func (goRestfulAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := goRestfulHandler
+	if write {
+		h = goRestfulHandlerWrite
+	}
+	path = paramRe.ReplaceAllString(path, "{$1}")
+	path = wildcardRe.ReplaceAllString(path, "{$1:*}")
+	return loadGoRestfulSingle(method, path, h)
+}
+
+func (goRestfulAdapter) Add(router http.Handler, r route) {
+	h := goRestfulHandler
+	if loadTestHandler {
+		h = goRestfulHandlerTest
+	}
+	path := paramRe.ReplaceAllString(r.path, "{$1}")
+	path = wildcardRe.ReplaceAllString(path, "{$1:*}")
+	ws := router.(*restful.Container).RegisteredWebServices()[0]
+	ws.Route(ws.Method(r.method).Path(path).To(h))
+}
+
+func goRestfulHandler(r *restful.Request, w *restful.Response) {}
+
+func goRestfulHandlerWrite(r *restful.Request, w *restful.Response) {
+	io.WriteString(w, r.PathParameter("name"))
+}
+
+func goRestfulHandlerTest(r *restful.Request, w *restful.Response) {
+	io.WriteString(w, r.Request.RequestURI)
+}
+
+func goRestfulHandlerParams(path string) restful.RouteFunction {
+	return func(r *restful.Request, w *restful.Response) {
+		writeParams(w, path, func(name string, _ bool) string {
+			return r.PathParameter(name)
+		})
+	}
+}
+
+func goRestfulMiddleware(r *restful.Request, w *restful.Response, chain *restful.FilterChain) {
+	chain.ProcessFilter(r, w)
+}
+
+func goRestfulMiddlewareTest(r *restful.Request, w *restful.Response, chain *restful.FilterChain) {
+	middlewareCalls.Add(1)
+	chain.ProcessFilter(r, w)
+}
+
+func loadGoRestful(routes []route) http.Handler {
+	h := goRestfulHandler
+	if loadTestHandler {
+		h = goRestfulHandlerTest
+	}
+
+	wsContainer := restful.NewContainer()
+	mw := goRestfulMiddleware
+	if loadTestHandler {
+		mw = goRestfulMiddlewareTest
+	}
+	for range loadMiddlewares {
+		wsContainer.Filter(mw)
+	}
+	ws := new(restful.WebService)
+
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = goRestfulHandlerParams(route.path)
+		}
+
+		path := paramRe.ReplaceAllString(route.path, "{$1}")
+		path = wildcardRe.ReplaceAllString(path, "{$1:*}")
+
+		switch route.method {
+		case http.MethodGet:
+			ws.Route(ws.GET(path).To(h))
+		case http.MethodPost:
+			ws.Route(ws.POST(path).To(h))
+		case http.MethodPut:
+			ws.Route(ws.PUT(path).To(h))
+		case http.MethodPatch:
+			ws.Route(ws.PATCH(path).To(h))
+		case http.MethodDelete:
+			ws.Route(ws.DELETE(path).To(h))
+		default:
+			panic("Unknow HTTP method: " + route.method)
+		}
+	}
+	wsContainer.Add(ws)
+	return wsContainer
+}
+
+func loadGoRestfulSingle(method, path string, handler restful.RouteFunction) http.Handler {
+	wsContainer := restful.NewContainer()
+	ws := new(restful.WebService)
+	switch method {
+	case http.MethodGet:
+		ws.Route(ws.GET(path).To(handler))
+	case http.MethodPost:
+		ws.Route(ws.POST(path).To(handler))
+	case http.MethodPut:
+		ws.Route(ws.PUT(path).To(handler))
+	case http.MethodPatch:
+		ws.Route(ws.PATCH(path).To(handler))
+	case http.MethodDelete:
+		ws.Route(ws.DELETE(path).To(handler))
+	default:
+		panic("Unknow HTTP method: " + method)
+	}
+	wsContainer.Add(ws)
+	return wsContainer
+}
@@ -0,0 +1,109 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func init() {
+	register(gorillaMuxAdapter{})
+}
+
+type gorillaMuxAdapter struct{}
+
+func (gorillaMuxAdapter) Name() string { return "GorillaMux" }
+func (gorillaMuxAdapter) Features() Feature {
+	return FeatureParams | FeatureWildcard | FeatureMixedSegments |
+		FeatureMiddleware | FeatureAdd | FeatureReverse
+}
+
+func (gorillaMuxAdapter) Load(routes []route) http.Handler {
+	return loadGorillaMux(routes)
+}
+
+func (gorillaMuxAdapter) Reverse(router http.Handler) reverseFunc {
+	m := router.(*mux.Router)
+	return func(name string, pairs []string) string {
+		r := m.Get(name)
+		if r == nil {
+			return ""
+		}
+		u, err := r.URLPath(pairs...)
+		if err != nil {
+			return ""
+		}
+		return u.Path
+	}
+}
+
+func (gorillaMuxAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := httpHandlerFunc
+	if write {
+		h = gorillaHandlerWrite
+	}
+	path = paramRe.ReplaceAllString(path, "{$1}")
+	path = wildcardRe.ReplaceAllString(path, "{$1:.*}")
+	return loadGorillaMuxSingle(method, path, h)
+}
+
+func (gorillaMuxAdapter) Add(router http.Handler, r route) {
+	h := httpHandlerFunc
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+	path := paramRe.ReplaceAllString(r.path, "{$1}")
+	path = wildcardRe.ReplaceAllString(path, "{$1:.*}")
+	router.(*mux.Router).HandleFunc(path, h).Methods(r.method)
+}
+
+func gorillaHandlerWrite(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	io.WriteString(w, params["name"])
+}
+
+func gorillaHandlerParams(path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		writeParams(w, path, func(name string, _ bool) string {
+			return params[name]
+		})
+	}
+}
+
+func loadGorillaMux(routes []route) http.Handler {
+	h := httpHandlerFunc
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+
+	m := mux.NewRouter()
+	mw := standardMiddleware()
+	for range loadMiddlewares {
+		m.Use(mw)
+	}
+	for i, route := range routes {
+		if loadParamsHandler {
+			h = gorillaHandlerParams(route.path)
+		}
+
+		path := paramRe.ReplaceAllString(route.path, "{$1}")
+		path = wildcardRe.ReplaceAllString(path, "{$1:.*}")
+		r := m.HandleFunc(path, h).Methods(route.method)
+		if loadRouteNames {
+			r.Name(routeName(i))
+		}
+	}
+	return m
+}
+
+func loadGorillaMuxSingle(method, path string, handler http.HandlerFunc) http.Handler {
+	m := mux.NewRouter()
+	m.HandleFunc(path, handler).Methods(method)
+	return m
+}
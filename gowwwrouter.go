@@ -0,0 +1,98 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	gowwwrouter "github.com/gowww/router"
+)
+
+func init() {
+	register(gowwwRouterAdapter{})
+}
+
+type gowwwRouterAdapter struct{}
+
+func (gowwwRouterAdapter) Name() string { return "GowwwRouter" }
+func (gowwwRouterAdapter) Features() Feature {
+	return FeatureParams | FeatureWildcard | FeatureAdd
+}
+
+func (gowwwRouterAdapter) Unsupported(f Feature) string {
+	switch f {
+	case FeatureMixedSegments:
+		return "matches the parameter instead of the static segment of routes registered before it"
+	}
+	return ""
+}
+
+func (gowwwRouterAdapter) Load(routes []route) http.Handler {
+	return loadGowwwRouter(routes)
+}
+
+func (gowwwRouterAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := http.HandlerFunc(httpHandlerFunc)
+	if write {
+		h = gowwwRouterHandleWrite
+		if wildcardRe.MatchString(path) {
+			h = gowwwRouterHandleWriteWildcard
+		}
+	}
+	path = wildcardRe.ReplaceAllString(path, "")
+	return loadGowwwRouterSingle(method, path, h)
+}
+
+func (gowwwRouterAdapter) Add(router http.Handler, r route) {
+	h := httpHandlerFunc
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+	router.(*gowwwrouter.Router).Handle(r.method, wildcardRe.ReplaceAllString(r.path, ""), http.HandlerFunc(h))
+}
+
+func gowwwRouterHandleWrite(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, gowwwrouter.Parameter(r, "name"))
+}
+
+func gowwwRouterHandleWriteWildcard(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, gowwwrouter.Parameter(r, "*"))
+}
+
+func gowwwRouterHandleParams(path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeParams(w, path, func(name string, catchAll bool) string {
+			if catchAll {
+				return gowwwrouter.Parameter(r, "*")
+			}
+			return gowwwrouter.Parameter(r, name)
+		})
+	}
+}
+
+func loadGowwwRouter(routes []route) http.Handler {
+	h := httpHandlerFunc
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+
+	router := gowwwrouter.New()
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = gowwwRouterHandleParams(route.path)
+		}
+
+		path := wildcardRe.ReplaceAllString(route.path, "")
+		router.Handle(route.method, path, http.HandlerFunc(h))
+	}
+	return router
+}
+
+func loadGowwwRouterSingle(method, path string, handler http.Handler) http.Handler {
+	router := gowwwrouter.New()
+	router.Handle(method, path, handler)
+	return router
+}
@@ -0,0 +1,35 @@
+// Copyright 2013 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"net/http"
+)
+
+// Google+
+// https://developers.google.com/+/api/latest/
+// (in reality this is just a subset of a much larger API)
+var gplusAPI = []route{
+	// People
+	{http.MethodGet, "/people/:userId"},
+	{http.MethodGet, "/people"},
+	{http.MethodGet, "/activities/:activityId/people/:collection"},
+	{http.MethodGet, "/people/:userId/people/:collection"},
+	{http.MethodGet, "/people/:userId/openIdConnect"},
+
+	// Activities
+	{http.MethodGet, "/people/:userId/activities/:collection"},
+	{http.MethodGet, "/activities/:activityId"},
+	{http.MethodGet, "/activities"},
+
+	// Comments
+	{http.MethodGet, "/activities/:activityId/comments"},
+	{http.MethodGet, "/comments/:commentId"},
+
+	// Moments
+	{http.MethodPost, "/people/:userId/moments/:collection"},
+	{http.MethodGet, "/people/:userId/moments/:collection"},
+	{http.MethodDelete, "/moments/:id"},
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// h2cStreams is the number of concurrent streams the load generator of the
+// HTTP/2 benchmarks sends requests on.
+var h2cStreams = 64
+
+// h2cBenchmarks are the names of the benchmarks added by addH2CBenchmarks.
+var h2cBenchmarks []string
+
+// addH2CBenchmarks adds an HTTP/2 end-to-end benchmark for each API, named
+// after the API, e.g. GithubH2C.
+func addH2CBenchmarks() {
+	for _, api := range apis {
+		name := benchPrefix(api.name) + "H2C"
+		benchmarks = append(benchmarks, h2cBenchmark(name, api.name))
+		h2cBenchmarks = append(h2cBenchmarks, name)
+	}
+}
+
+// h2cBenchmark is the HTTP/2 variant of e2eBenchmark, see benchH2C.
+func h2cBenchmark(name, apiName string) benchmark {
+	return benchmark{
+		name:     name,
+		api:      apiName,
+		parallel: true,
+		supports: func(a Adapter) bool {
+			return canServe(a, findAPI(apiName).routes)
+		},
+		run: func(b *testing.B, a Adapter) {
+			api := findAPI(apiName)
+			benchH2C(b, apiHandler(api, a), requests(api.routes))
+		},
+	}
+}
+
+// newH2CServer starts a loopback server speaking only unencrypted HTTP/2,
+// known as h2c, and returns it with a client speaking the same.
+func newH2CServer(router http.Handler) (*httptest.Server, *http.Client) {
+	srv := httptest.NewUnstartedServer(router)
+	srv.Config.Protocols = new(http.Protocols)
+	srv.Config.Protocols.SetUnencryptedHTTP2(true)
+	srv.Start()
+
+	tr := &http.Transport{Protocols: new(http.Protocols)}
+	tr.Protocols.SetUnencryptedHTTP2(true)
+	client := &http.Client{Transport: tr}
+
+	// Concurrent requests without a connection each dial one, so the
+	// connection is established first, with a request the server answers
+	// without the router
+	req, _ := http.NewRequest(http.MethodOptions, srv.URL, nil)
+	req.URL.Opaque = "*"
+	if resp, err := client.Do(req); err == nil {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	return srv, client
+}
+
+// benchH2C serves the router with h2c and sends the requests to it over
+// h2cStreams concurrent streams, multiplexed over a single connection. It
+// reports like runLoad.
+func benchH2C(b *testing.B, router http.Handler, reqs []route) {
+	srv, client := newH2CServer(router)
+	defer srv.Close()
+	defer client.CloseIdleConnections()
+
+	urls := make([]string, len(reqs))
+	for i, r := range reqs {
+		urls[i] = srv.URL + r.path
+	}
+
+	runLoad(b, reqs, h2cStreams, func(_, i int) (int, error) {
+		req, err := http.NewRequest(reqs[i].method, urls[i], nil)
+		if err != nil {
+			return 0, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if resp.ProtoMajor != 2 {
+			return 0, fmt.Errorf("got a response over %s, expected HTTP/2", resp.Proto)
+		}
+		return resp.StatusCode, nil
+	})
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// TestRoutersH2C checks that the routers route the requests of the APIs when
+// served with h2c, with concurrent streams on one connection.
+func TestRoutersH2C(t *testing.T) {
+	loadTestHandler = true
+	defer func() { loadTestHandler = false }()
+
+	for _, router := range adapters {
+		t.Run(router.Name(), func(t *testing.T) {
+			for _, name := range []string{"GitHub", "Static"} {
+				api := findAPI(name)
+				if !canServe(router, api.routes) {
+					continue
+				}
+				srv, client := newH2CServer(router.Load(api.routes))
+				defer srv.Close()
+				defer client.CloseIdleConnections()
+
+				var wg sync.WaitGroup
+				for _, route := range requests(api.routes) {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						req, _ := http.NewRequest(route.method, srv.URL+route.path, nil)
+						resp, err := client.Do(req)
+						if err != nil {
+							t.Error(err)
+							return
+						}
+						body, _ := io.ReadAll(resp.Body)
+						resp.Body.Close()
+						if resp.ProtoMajor != 2 || resp.StatusCode != 200 || string(body) != route.path {
+							t.Errorf("API %s: %s %d - %s; expected %s %s", api.name, resp.Proto, resp.StatusCode, body, route.method, route.path)
+						}
+					}()
+				}
+				wg.Wait()
+			}
+		})
+	}
+}
+
+func TestBenchH2C(t *testing.T) {
+	h := httpRouterAdapter{}.Load(githubAPI)
+	r := testing.Benchmark(func(b *testing.B) {
+		benchH2C(b, h, requests(githubAPI))
+	})
+	if r.N == 0 {
+		t.Fatal("the benchmark failed")
+	}
+	for _, unit := range []string{"req/s", "p50-ns", "p99.9-ns"} {
+		if r.Extra[unit] <= 0 {
+			t.Errorf("got %v %s, expected more than 0", r.Extra[unit], unit)
+		}
+	}
+}
+
+func TestH2CSingleConnection(t *testing.T) {
+	// The remote addresses of the connections
+	var addrs sync.Map
+	srv, client := newH2CServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addrs.Store(r.RemoteAddr, true)
+	}))
+	defer srv.Close()
+	defer client.CloseIdleConnections()
+
+	var wg sync.WaitGroup
+	for range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(srv.URL + "/")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	n := 0
+	addrs.Range(func(_, _ any) bool {
+		n++
+		return true
+	})
+	if n != 1 {
+		t.Errorf("the streams used %d connections, expected 1", n)
+	}
+}
+
+// All routes of each API served with h2c, added with -h2c
+func BenchmarkH2C(b *testing.B) {
+	for _, name := range h2cBenchmarks {
+		b.Run(name, func(b *testing.B) {
+			runBenchmark(b, name)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func init() {
+	register(httpRouterAdapter{})
+}
+
+type httpRouterAdapter struct{}
+
+func (httpRouterAdapter) Name() string { return "HttpRouter" }
+func (httpRouterAdapter) Features() Feature {
+	return FeatureParams | FeatureMethodNotAllowed | FeatureWildcard | FeatureAdd
+}
+
+func (httpRouterAdapter) Unsupported(f Feature) string {
+	switch f {
+	case FeatureMixedSegments:
+		return "panics when a static segment and a parameter share a position"
+	}
+	return ""
+}
+
+func (httpRouterAdapter) Load(routes []route) http.Handler {
+	return loadHttpRouter(routes)
+}
+
+func (httpRouterAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := httpRouterHandle
+	if write {
+		h = httpRouterHandleWrite
+	}
+	return loadHttpRouterSingle(method, path, h)
+}
+
+func (httpRouterAdapter) Add(router http.Handler, r route) {
+	h := httpRouterHandle
+	if loadTestHandler {
+		h = httpRouterHandleTest
+	}
+	router.(*httprouter.Router).Handle(r.method, r.path, h)
+}
+
+func httpRouterHandle(_ http.ResponseWriter, _ *http.Request, _ httprouter.Params) {}
+
+func httpRouterHandleWrite(w http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
+	io.WriteString(w, ps.ByName("name"))
+}
+
+func httpRouterHandleTest(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	io.WriteString(w, r.RequestURI)
+}
+
+func httpRouterHandleParams(path string) httprouter.Handle {
+	return func(w http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
+		writeParams(w, path, func(name string, _ bool) string {
+			return ps.ByName(name)
+		})
+	}
+}
+
+func loadHttpRouter(routes []route) http.Handler {
+	h := httpRouterHandle
+	if loadTestHandler {
+		h = httpRouterHandleTest
+	}
+
+	router := httprouter.New()
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = httpRouterHandleParams(route.path)
+		}
+		router.Handle(route.method, route.path, h)
+	}
+	return router
+}
+
+func loadHttpRouterSingle(method, path string, handle httprouter.Handle) http.Handler {
+	router := httprouter.New()
+	router.Handle(method, path, handle)
+	return router
+}
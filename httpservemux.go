@@ -0,0 +1,92 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+)
+
+func init() {
+	register(httpServeMuxAdapter{})
+}
+
+type httpServeMuxAdapter struct{}
+
+func (httpServeMuxAdapter) Name() string { return "HttpServeMux" }
+func (httpServeMuxAdapter) Features() Feature {
+	return FeatureParams | FeatureMethodNotAllowed | FeatureWildcard | FeatureMixedSegments |
+		FeatureAdd | FeatureConcurrentAdd
+}
+
+func (httpServeMuxAdapter) Deviation() deviation {
+	return deviation{allow: "lists the methods of catch-all routes for the path without the trailing slash, which it redirects"}
+}
+
+func (httpServeMuxAdapter) Load(routes []route) http.Handler {
+	return loadHttpServeMux(routes)
+}
+
+func (httpServeMuxAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := httpHandlerFunc
+	if write {
+		h = httpServeMuxHandlerWrite
+	}
+	return loadHttpServeMuxSingle(httpServeMuxPattern(method, path), h)
+}
+
+func (httpServeMuxAdapter) Add(router http.Handler, r route) {
+	h := httpHandlerFunc
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+	router.(*http.ServeMux).HandleFunc(httpServeMuxPattern(r.method, r.path), h)
+}
+
+func httpServeMuxHandlerWrite(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, r.PathValue("name"))
+}
+
+func httpServeMuxHandlerParams(path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeParams(w, path, func(name string, _ bool) string {
+			return r.PathValue(name)
+		})
+	}
+}
+
+// httpServeMuxPattern translates the route to a ServeMux pattern with the
+// method, like "GET /users/{user}/repos/{path...}".
+func httpServeMuxPattern(method, path string) string {
+	path = paramRe.ReplaceAllString(path, "{$1}")
+	path = wildcardRe.ReplaceAllString(path, "{$1...}")
+	if path == "/" {
+		// Only match the root, not every path
+		path = "/{$}"
+	}
+	return method + " " + path
+}
+
+func loadHttpServeMux(routes []route) http.Handler {
+	h := httpHandlerFunc
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+
+	serveMux := http.NewServeMux()
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = httpServeMuxHandlerParams(route.path)
+		}
+		serveMux.HandleFunc(httpServeMuxPattern(route.method, route.path), h)
+	}
+	return serveMux
+}
+
+func loadHttpServeMuxSingle(pattern string, handler http.HandlerFunc) http.Handler {
+	serveMux := http.NewServeMux()
+	serveMux.HandleFunc(pattern, handler)
+	return serveMux
+}
@@ -0,0 +1,84 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/dimfeld/httptreemux/v5"
+)
+
+func init() {
+	register(httpTreeMuxAdapter{})
+}
+
+type httpTreeMuxAdapter struct{}
+
+func (httpTreeMuxAdapter) Name() string { return "HttpTreeMux" }
+func (httpTreeMuxAdapter) Features() Feature {
+	return FeatureParams | FeatureMethodNotAllowed | FeatureWildcard | FeatureMixedSegments |
+		FeatureAdd
+}
+
+func (httpTreeMuxAdapter) Load(routes []route) http.Handler {
+	return loadHttpTreeMux(routes)
+}
+
+func (httpTreeMuxAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := httpTreeMuxHandler
+	if write {
+		h = httpTreeMuxHandlerWrite
+	}
+	return loadHttpTreeMuxSingle(method, path, h)
+}
+
+func (httpTreeMuxAdapter) Add(router http.Handler, r route) {
+	h := httpTreeMuxHandler
+	if loadTestHandler {
+		h = httpTreeMuxHandlerTest
+	}
+	router.(*httptreemux.TreeMux).Handle(r.method, r.path, h)
+}
+
+func httpTreeMuxHandler(_ http.ResponseWriter, _ *http.Request, _ map[string]string) {}
+
+func httpTreeMuxHandlerWrite(w http.ResponseWriter, _ *http.Request, vars map[string]string) {
+	io.WriteString(w, vars["name"])
+}
+
+func httpTreeMuxHandlerTest(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	io.WriteString(w, r.RequestURI)
+}
+
+func httpTreeMuxHandlerParams(path string) httptreemux.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request, vars map[string]string) {
+		writeParams(w, path, func(name string, _ bool) string {
+			return vars[name]
+		})
+	}
+}
+
+func loadHttpTreeMux(routes []route) http.Handler {
+	h := httpTreeMuxHandler
+	if loadTestHandler {
+		h = httpTreeMuxHandlerTest
+	}
+
+	router := httptreemux.New()
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = httpTreeMuxHandlerParams(route.path)
+		}
+		router.Handle(route.method, route.path, h)
+	}
+	return router
+}
+
+func loadHttpTreeMuxSingle(method, path string, handler httptreemux.HandlerFunc) http.Handler {
+	router := httptreemux.New()
+	router.Handle(method, path, handler)
+	return router
+}
@@ -0,0 +1,164 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	urlrouter "github.com/naoina/kocha-urlrouter"
+	_ "github.com/naoina/kocha-urlrouter/doublearray"
+)
+
+func init() {
+	register(kochaAdapter{})
+}
+
+type kochaAdapter struct{}
+
+func (kochaAdapter) Name() string { return "Kocha" }
+func (kochaAdapter) Features() Feature {
+	return FeatureParams | FeatureWildcard | FeatureMixedSegments
+}
+
+func (kochaAdapter) Unsupported(f Feature) string {
+	switch f {
+	case FeatureAdd:
+		return "routes are compiled by Build"
+	}
+	return ""
+}
+
+func (kochaAdapter) Deviation() deviation {
+	return deviation{
+		notFound:    "the lookup panics for some paths",
+		wrongMethod: "the lookup panics for some paths",
+		panics:      true,
+	}
+}
+
+func (kochaAdapter) Load(routes []route) http.Handler {
+	return loadKocha(routes)
+}
+
+func (kochaAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	handler := new(kochaHandler)
+	h := kochaHandle(handler.Get)
+	if write {
+		h = handler.kochaHandlerWrite
+	}
+	return loadKochaSingle(method, path, handler, h)
+}
+
+// kochaHandle is the type of the handlers in the routers. They get the
+// parameters of their request from ServeHTTP, since the handler is shared by
+// concurrent requests.
+type kochaHandle func(w http.ResponseWriter, r *http.Request, params []urlrouter.Param)
+
+type kochaHandler struct {
+	routerMap map[string]urlrouter.URLRouter
+}
+
+func (h *kochaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	router, ok := h.routerMap[r.Method]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	meth, params := router.Lookup(r.URL.Path)
+	if meth == nil {
+		http.NotFound(w, r)
+		return
+	}
+	meth.(kochaHandle)(w, r, params)
+}
+
+func (h *kochaHandler) Get(w http.ResponseWriter, r *http.Request, params []urlrouter.Param)    {}
+func (h *kochaHandler) Post(w http.ResponseWriter, r *http.Request, params []urlrouter.Param)   {}
+func (h *kochaHandler) Put(w http.ResponseWriter, r *http.Request, params []urlrouter.Param)    {}
+func (h *kochaHandler) Patch(w http.ResponseWriter, r *http.Request, params []urlrouter.Param)  {}
+func (h *kochaHandler) Delete(w http.ResponseWriter, r *http.Request, params []urlrouter.Param) {}
+func (h *kochaHandler) kochaHandlerWrite(w http.ResponseWriter, r *http.Request, params []urlrouter.Param) {
+	var name string
+	for _, param := range params {
+		if param.Name == "name" {
+			name = param.Value
+			break
+		}
+	}
+	io.WriteString(w, name)
+}
+
+func kochaHandlerTest(w http.ResponseWriter, r *http.Request, _ []urlrouter.Param) {
+	httpHandlerFuncTest(w, r)
+}
+
+func kochaHandlerParams(path string) kochaHandle {
+	return func(w http.ResponseWriter, r *http.Request, params []urlrouter.Param) {
+		writeParams(w, path, func(name string, _ bool) string {
+			for _, param := range params {
+				if param.Name == name {
+					return param.Value
+				}
+			}
+			return ""
+		})
+	}
+}
+
+func loadKocha(routes []route) http.Handler {
+	handler := &kochaHandler{routerMap: map[string]urlrouter.URLRouter{
+		http.MethodGet:    urlrouter.NewURLRouter("doublearray"),
+		http.MethodPost:   urlrouter.NewURLRouter("doublearray"),
+		http.MethodPut:    urlrouter.NewURLRouter("doublearray"),
+		http.MethodPatch:  urlrouter.NewURLRouter("doublearray"),
+		http.MethodDelete: urlrouter.NewURLRouter("doublearray"),
+	}}
+	recordMap := make(map[string][]urlrouter.Record)
+	for _, route := range routes {
+		var f kochaHandle
+		switch route.method {
+		case http.MethodGet:
+			f = handler.Get
+		case http.MethodPost:
+			f = handler.Post
+		case http.MethodPut:
+			f = handler.Put
+		case http.MethodPatch:
+			f = handler.Patch
+		case http.MethodDelete:
+			f = handler.Delete
+		}
+		if loadTestHandler {
+			f = kochaHandlerTest
+		}
+		if loadParamsHandler {
+			f = kochaHandlerParams(route.path)
+		}
+		recordMap[route.method] = append(
+			recordMap[route.method],
+			urlrouter.NewRecord(route.path, f),
+		)
+	}
+	for method, records := range recordMap {
+		if err := handler.routerMap[method].Build(records); err != nil {
+			panic(err)
+		}
+	}
+	return handler
+}
+
+func loadKochaSingle(method, path string, handler *kochaHandler, hfunc kochaHandle) http.Handler {
+	handler.routerMap = map[string]urlrouter.URLRouter{
+		method: urlrouter.NewURLRouter("doublearray"),
+	}
+
+	if err := handler.routerMap[method].Build([]urlrouter.Record{
+		urlrouter.NewRecord(path, hfunc),
+	}); err != nil {
+		panic(err)
+	}
+	return handler
+}
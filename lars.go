@@ -0,0 +1,148 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/go-playground/lars"
+)
+
+func init() {
+	register(larsAdapter{})
+}
+
+type larsAdapter struct{}
+
+func (larsAdapter) Name() string { return "LARS" }
+func (larsAdapter) Features() Feature {
+	return FeatureParams | FeatureWildcard | FeatureMiddleware
+}
+
+func (larsAdapter) Unsupported(f Feature) string {
+	switch f {
+	case FeatureMixedSegments:
+		return "panics when a static segment and a parameter share a position"
+	case FeatureAdd:
+		return "Load returns the handler of Serve, not the router"
+	}
+	return ""
+}
+
+func (larsAdapter) Load(routes []route) http.Handler {
+	return loadLARS(routes)
+}
+
+func (larsAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	var h interface{} = larsHandler
+	if write {
+		h = larsHandlerWrite
+		if wildcardRe.MatchString(path) {
+			h = larsHandlerWriteWildcard
+		}
+	}
+	path = wildcardRe.ReplaceAllString(path, "*")
+	return loadLARSSingle(method, path, h)
+}
+
+func larsHandler(c lars.Context) {
+}
+
+func larsHandlerWrite(c lars.Context) {
+	io.WriteString(c.Response(), c.Param("name"))
+}
+
+func larsHandlerWriteWildcard(c lars.Context) {
+	io.WriteString(c.Response(), c.Param(lars.WildcardParam))
+}
+
+func larsHandlerTest(c lars.Context) {
+	io.WriteString(c.Response(), c.Request().RequestURI)
+}
+
+func larsNativeHandlerTest(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, r.RequestURI)
+}
+
+func larsHandlerParams(path string) func(lars.Context) {
+	return func(c lars.Context) {
+		writeParams(c.Response(), path, func(name string, catchAll bool) string {
+			if catchAll {
+				return c.Param(lars.WildcardParam)
+			}
+			return c.Param(name)
+		})
+	}
+}
+
+func larsMiddleware(c lars.Context) {
+	c.Next()
+}
+
+func larsMiddlewareTest(c lars.Context) {
+	middlewareCalls.Add(1)
+	c.Next()
+}
+
+func loadLARS(routes []route) http.Handler {
+	var h interface{} = larsHandler
+	if loadTestHandler {
+		h = larsHandlerTest
+	}
+
+	l := lars.New()
+	mw := larsMiddleware
+	if loadTestHandler {
+		mw = larsMiddlewareTest
+	}
+	for range loadMiddlewares {
+		l.Use(mw)
+	}
+
+	for _, r := range routes {
+		if loadParamsHandler {
+			h = larsHandlerParams(r.path)
+		}
+
+		path := wildcardRe.ReplaceAllString(r.path, "*")
+
+		switch r.method {
+		case http.MethodGet:
+			l.Get(path, h)
+		case http.MethodPost:
+			l.Post(path, h)
+		case http.MethodPut:
+			l.Put(path, h)
+		case http.MethodPatch:
+			l.Patch(path, h)
+		case http.MethodDelete:
+			l.Delete(path, h)
+		default:
+			panic("Unknow HTTP method: " + r.method)
+		}
+	}
+	return l.Serve()
+}
+
+func loadLARSSingle(method, path string, h interface{}) http.Handler {
+	l := lars.New()
+
+	switch method {
+	case http.MethodGet:
+		l.Get(path, h)
+	case http.MethodPost:
+		l.Post(path, h)
+	case http.MethodPut:
+		l.Put(path, h)
+	case http.MethodPatch:
+		l.Patch(path, h)
+	case http.MethodDelete:
+		l.Delete(path, h)
+	default:
+		panic("Unknow HTTP method: " + method)
+	}
+	return l.Serve()
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HdrHistogram/hdrhistogram-go"
+)
+
+// latencyMode makes benchRequest, benchRoutes and benchStream record the
+// latency of every request and report its percentiles, see benchLatency. The
+// replay benchmarks always do.
+var latencyMode bool
+
+// latencyPercentiles are the percentiles of the request latency reported by
+// benchLatency, with the units of their metrics.
+var latencyPercentiles = []struct {
+	unit       string
+	percentile float64
+}{
+	{"p50-ns", 50},
+	{"p90-ns", 90},
+	{"p99-ns", 99},
+	{"p99.9-ns", 99.9},
+}
+
+// maxLatency is the highest latency the histograms record, higher ones are
+// recorded as maxLatency.
+const maxLatency = 10 * time.Second
+
+// newLatencyHistogram returns a histogram of latencies in nanoseconds from 1ns
+// to maxLatency, with 3 significant digits.
+func newLatencyHistogram() *hdrhistogram.Histogram {
+	return hdrhistogram.New(1, int64(maxLatency), 3)
+}
+
+// benchLatency routes perOp of the requests per operation, cycling through
+// them, records the latency of each request in an HDR histogram and reports
+// the latencyPercentiles as metrics. Timing each request adds the overhead of
+// reading the clock to the latencies and to the time per operation.
+func benchLatency(b *testing.B, router http.Handler, reqs []route, perOp int) {
+	w := selectedWriter.new()
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+	u := r.URL
+	rq := u.RawQuery
+	h := newLatencyHistogram()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	next := 0
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < perOp; j++ {
+			route := reqs[next]
+			if next++; next == len(reqs) {
+				next = 0
+			}
+			r.Method = route.method
+			r.RequestURI = route.path
+			u.Path = route.path
+			u.RawQuery = rq
+			start := time.Now()
+			router.ServeHTTP(w, r)
+			w.finish()
+			h.RecordValue(int64(min(time.Since(start), maxLatency)))
+		}
+	}
+
+	b.StopTimer()
+	reportLatency(b, h)
+}
+
+// reportLatency reports the latencyPercentiles of the histogram as metrics.
+func reportLatency(b *testing.B, h *hdrhistogram.Histogram) {
+	for _, p := range latencyPercentiles {
+		b.ReportMetric(float64(h.ValueAtQuantile(p.percentile)), p.unit)
+	}
+}
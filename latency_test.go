@@ -0,0 +1,42 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// sleepHandler takes 1ms for every 100th request.
+type sleepHandler struct{ n int }
+
+func (h *sleepHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.n++; h.n%100 == 0 {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBenchLatency(t *testing.T) {
+	r := testing.Benchmark(func(b *testing.B) {
+		benchLatency(b, new(sleepHandler), []route{{http.MethodGet, "/"}}, 1)
+	})
+
+	var last float64
+	for _, p := range latencyPercentiles {
+		v, ok := r.Extra[p.unit]
+		if !ok {
+			t.Fatalf("no %s metric in %v", p.unit, r.Extra)
+		}
+		if v < last {
+			t.Errorf("%s is %v, less than the previous percentile %v", p.unit, v, last)
+		}
+		last = v
+	}
+	if p90, p999 := r.Extra["p90-ns"], r.Extra["p99.9-ns"]; p90 >= float64(time.Millisecond) || p999 < float64(time.Millisecond) {
+		t.Errorf("got p90 %vns and p99.9 %vns, expected the 1ms requests only in the p99.9", p90, p999)
+	}
+
+	res := newResult(benchmark{name: "Test"}, httpServeMuxAdapter{}, r)
+	if res.P50Ns != r.Extra["p50-ns"] || res.P999Ns != r.Extra["p99.9-ns"] {
+		t.Errorf("the result has the percentiles %v and %v, expected %v", res.P50Ns, res.P999Ns, r.Extra)
+	}
+}
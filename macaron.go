@@ -0,0 +1,138 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"gopkg.in/macaron.v1"
+)
+
+func init() {
+	register(macaronAdapter{})
+}
+
+type macaronAdapter struct{}
+
+func (macaronAdapter) Name() string { return "Macaron" }
+func (macaronAdapter) Features() Feature {
+	return FeatureParams | FeatureWildcard | FeatureMixedSegments |
+		FeatureMiddleware | FeatureAdd | FeatureReverse
+}
+
+func (macaronAdapter) Load(routes []route) http.Handler {
+	return loadMacaron(routes)
+}
+
+func (macaronAdapter) Reverse(router http.Handler) reverseFunc {
+	m := router.(*macaron.Macaron)
+	return func(name string, pairs []string) string {
+		// URLFor takes the names with the colon, as in the route path
+		p := make([]string, len(pairs))
+		for i := 0; i < len(pairs); i += 2 {
+			p[i] = macaronParam(":" + pairs[i])
+			p[i+1] = pairs[i+1]
+		}
+		return m.URLFor(name, p...)
+	}
+}
+
+func (macaronAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	var h interface{} = macaronHandler
+	if write {
+		h = macaronHandlerWrite
+		if wildcardRe.MatchString(path) {
+			h = macaronHandlerWriteWildcard
+		}
+	}
+	path = macaronPath(path)
+	return loadMacaronSingle(method, path, h)
+}
+
+func (macaronAdapter) Add(router http.Handler, r route) {
+	h := []macaron.Handler{macaronHandler}
+	if loadTestHandler {
+		h[0] = macaronHandlerTest
+	}
+	router.(*macaron.Macaron).Handle(r.method, macaronPath(r.path), h)
+}
+
+func macaronHandler() {}
+
+func macaronHandlerWrite(c *macaron.Context) string {
+	return c.Params("name")
+}
+
+func macaronHandlerWriteWildcard(c *macaron.Context) string {
+	return c.Params("*")
+}
+
+func macaronHandlerTest(c *macaron.Context) string {
+	return c.Req.RequestURI
+}
+
+func macaronHandlerParams(path string) func(*macaron.Context) {
+	return func(c *macaron.Context) {
+		writeParams(c.Resp, path, func(name string, catchAll bool) string {
+			if catchAll {
+				return c.Params("*")
+			}
+			return c.Params(macaronParam(name))
+		})
+	}
+}
+
+func macaronMiddleware(c *macaron.Context) {
+	c.Next()
+}
+
+func macaronMiddlewareTest(c *macaron.Context) {
+	middlewareCalls.Add(1)
+	c.Next()
+}
+
+func loadMacaron(routes []route) http.Handler {
+	var h = []macaron.Handler{macaronHandler}
+	if loadTestHandler {
+		h[0] = macaronHandlerTest
+	}
+
+	m := macaron.New()
+	mw := macaronMiddleware
+	if loadTestHandler {
+		mw = macaronMiddlewareTest
+	}
+	for range loadMiddlewares {
+		m.Use(mw)
+	}
+	for i, route := range routes {
+		if loadParamsHandler {
+			h = []macaron.Handler{macaronHandlerParams(route.path)}
+		}
+		r := m.Handle(route.method, macaronPath(route.path), h)
+		if loadRouteNames {
+			r.Name(routeName(i))
+		}
+	}
+	return m
+}
+
+// macaronPath translates the parameters of the route path. Macaron only allows
+// letters and digits in parameter names, so underscores are removed.
+func macaronPath(path string) string {
+	path = paramRe.ReplaceAllStringFunc(path, macaronParam)
+	return wildcardRe.ReplaceAllString(path, "*")
+}
+
+func macaronParam(name string) string {
+	return strings.ReplaceAll(name, "_", "")
+}
+
+func loadMacaronSingle(method, path string, handler interface{}) http.Handler {
+	m := macaron.New()
+	m.Handle(method, path, []macaron.Handler{handler})
+	return m
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"text/tabwriter"
+)
+
+func main() {
+	opts.register(flag.CommandLine)
+	list := flag.Bool("list", false, "list the routers, APIs and benchmarks and exit")
+	features := flag.Bool("features", false, "check how the routers handle requests no route matches, print the feature matrix and exit")
+	routerNames := flag.String("routers", "", "comma-separated `names` of the routers to benchmark (default all)")
+	apiNames := flag.String("apis", "", "comma-separated `names` of the APIs to benchmark, skips the micro benchmarks (default all)")
+	benchNames := flag.String("bench", "", "comma-separated `names` of the benchmarks to run (default all)")
+	benchTime := flag.String("benchtime", "1s", "run each benchmark for `duration`, or N times if given as Nx")
+	count := flag.Int("count", 1, "run each benchmark `n` times, to collect samples for -compare")
+	jsonFile := flag.String("json", "", "write the results as JSON to `file`")
+	csvFile := flag.String("csv", "", "write the results as CSV to `file`")
+	markdownFile := flag.String("markdown", "", "write the results as the markdown tables of the README to `file`")
+	resultsFile := flag.String("results", "", "read the results from the JSON `file` written by -json instead of running the benchmarks")
+	compare := flag.Bool("compare", false, "compare the results of the two JSON files given as arguments and exit with status 1 on regressions")
+	threshold := flag.Float64("threshold", 5, "report significant slowdowns of more than `percent` as regressions")
+	alpha := flag.Float64("alpha", 0.05, "consider changes with a p-value below `alpha` significant")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags]\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "       %s -compare [-threshold percent] [-alpha alpha] old.json new.json\n\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "Runs the benchmarks like go test -bench=. would, but selected by name.")
+		fmt.Fprintln(flag.CommandLine.Output())
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := opts.apply(); err != nil {
+		fatalf("%v", err)
+	}
+	if *list {
+		printList()
+		return
+	}
+	if *features {
+		routers, err := selectRouters(splitNames(*routerNames))
+		if err != nil {
+			fatalf("%v", err)
+		}
+		loadTestHandler = true
+		if err := writeFeatures(os.Stdout, routers); err != nil {
+			fatalf("%v", err)
+		}
+		return
+	}
+	if opts.scale {
+		routers, err := selectRouters(splitNames(*routerNames))
+		if err != nil {
+			fatalf("%v", err)
+		}
+		setBenchTime(*benchTime)
+		points := runScale(routers, scaleSizes)
+		fmt.Println()
+		if err := writeScale(os.Stdout, points); err != nil {
+			fatalf("%v", err)
+		}
+		return
+	}
+	if *compare {
+		if flag.NArg() != 2 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		os.Exit(compareFiles(flag.Arg(0), flag.Arg(1), *alpha, *threshold/100))
+	}
+
+	var rs *resultSet
+	if *resultsFile != "" {
+		var err error
+		if rs, err = readResults(*resultsFile); err != nil {
+			fatalf("reading results: %v", err)
+		}
+	} else {
+		rs = run(*routerNames, *apiNames, *benchNames, *benchTime, *count)
+	}
+
+	if *jsonFile != "" {
+		if err := writeFile(*jsonFile, rs.WriteJSON); err != nil {
+			fatalf("writing results: %v", err)
+		}
+	}
+	if *csvFile != "" {
+		if err := writeFile(*csvFile, rs.WriteCSV); err != nil {
+			fatalf("writing results: %v", err)
+		}
+	}
+	if *markdownFile != "" {
+		if err := writeFile(*markdownFile, func(w io.Writer) error {
+			return writeMarkdown(w, rs)
+		}); err != nil {
+			fatalf("writing markdown: %v", err)
+		}
+	}
+}
+
+// run runs the benchmarks selected by the comma-separated names count times.
+func run(routerNames, apiNames, benchNames, benchTime string, count int) *resultSet {
+	setBenchTime(benchTime)
+	if count < 1 {
+		fatalf("invalid -count: %d", count)
+	}
+
+	routers, err := selectRouters(splitNames(routerNames))
+	if err != nil {
+		fatalf("%v", err)
+	}
+	benches, err := selectBenchmarks(splitNames(benchNames), splitNames(apiNames))
+	if err != nil {
+		fatalf("%v", err)
+	}
+	return runBenchmarks(benches, routers, count)
+}
+
+// setBenchTime sets the time testing.Benchmark runs each benchmark for.
+func setBenchTime(benchTime string) {
+	// Register the testing flags to pass the benchmark time on
+	testing.Init()
+	if err := flag.Set("test.benchtime", benchTime); err != nil {
+		fatalf("invalid -benchtime: %v", err)
+	}
+}
+
+// compareFiles compares the results of two runs and returns the exit status,
+// which is 1 if there are regressions.
+func compareFiles(oldFile, newFile string, alpha, threshold float64) int {
+	before, err := readResults(oldFile)
+	if err != nil {
+		fatalf("reading results: %v", err)
+	}
+	after, err := readResults(newFile)
+	if err != nil {
+		fatalf("reading results: %v", err)
+	}
+	cs := compareResults(before, after)
+	if len(cs) == 0 {
+		fatalf("no common benchmarks in %s and %s", oldFile, newFile)
+	}
+	n, err := writeComparison(os.Stdout, cs, alpha, threshold)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	if n > 0 {
+		return 1
+	}
+	return 0
+}
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(2)
+}
+
+func printList() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "Routers:")
+	for _, a := range adapters {
+		fmt.Fprintf(w, "  %s\n", a.Name())
+	}
+	fmt.Fprintln(w, "\nAPIs:")
+	for _, api := range apis {
+		fmt.Fprintf(w, "  %s\t%d routes\n", api.name, len(api.routes))
+	}
+	fmt.Fprintln(w, "\nBenchmarks:")
+	for _, bm := range benchmarks {
+		if bm.api == "" {
+			fmt.Fprintf(w, "  %s\n", bm.name)
+			continue
+		}
+		fmt.Fprintf(w, "  %s\t%s\n", bm.name, bm.api)
+	}
+	w.Flush()
+}
+
+// splitNames splits a comma-separated list of names.
+func splitNames(s string) []string {
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// selectRouters returns the named routers, or all if names is empty. Names
+// are matched case-insensitively.
+func selectRouters(names []string) ([]Adapter, error) {
+	if len(names) == 0 {
+		return adapters, nil
+	}
+	var selected []Adapter
+	for _, name := range names {
+		found := false
+		for _, a := range adapters {
+			if strings.EqualFold(a.Name(), name) {
+				selected = append(selected, a)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown router %q, see -list", name)
+		}
+	}
+	return selected, nil
+}
+
+// selectBenchmarks returns the benchmarks matching both the benchmark and
+// the API names. An empty list of names matches all.
+func selectBenchmarks(names, apiNames []string) ([]benchmark, error) {
+	for _, name := range apiNames {
+		found := false
+		for _, api := range apis {
+			if strings.EqualFold(api.name, name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown API %q, see -list", name)
+		}
+	}
+	for _, name := range names {
+		found := false
+		for _, bm := range benchmarks {
+			if strings.EqualFold(bm.name, name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown benchmark %q, see -list", name)
+		}
+	}
+
+	var selected []benchmark
+	for _, bm := range benchmarks {
+		if len(names) > 0 && !containsFold(names, bm.name) {
+			continue
+		}
+		if len(apiNames) > 0 && !containsFold(apiNames, bm.api) {
+			continue
+		}
+		selected = append(selected, bm)
+	}
+	return selected, nil
+}
+
+// parseSizes parses the route set sizes of -scale, or returns scaleSizes if
+// there are none.
+func parseSizes(s []string) ([]int, error) {
+	if len(s) == 0 {
+		return scaleSizes, nil
+	}
+	sizes := make([]int, len(s))
+	for i, size := range s {
+		n, err := strconv.Atoi(size)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid route set size %q", size)
+		}
+		sizes[i] = n
+	}
+	return sizes, nil
+}
+
+func containsFold(names []string, s string) bool {
+	for _, name := range names {
+		if strings.EqualFold(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// runBenchmarks runs each benchmark count times for every supporting router
+// and prints the results in the format of go test, so they can be processed by
+// the same tools. Like go test, it first prints the memory consumption of the
+// routers for each API to stderr.
+func runBenchmarks(benches []benchmark, routers []Adapter, count int) *resultSet {
+	mem := make(map[string]map[string]memStats)
+	for _, bm := range benches {
+		if bm.api == "" || mem[bm.api] != nil {
+			continue
+		}
+		api := findAPI(bm.api)
+		fmt.Fprintln(os.Stderr, "#"+api.name+" Routes:", len(api.routes))
+		mem[api.name] = make(map[string]memStats)
+		for _, a := range routers {
+			if !canServe(a, api.routes) {
+				continue
+			}
+			m, err := measureMem(func() http.Handler {
+				return a.Load(api.routes)
+			})
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "   "+a.Name()+": error:", err)
+				continue
+			}
+			mem[api.name][a.Name()] = m
+			fmt.Fprintln(os.Stderr, "   "+a.Name()+":", m)
+		}
+		fmt.Fprintln(os.Stderr)
+	}
+
+	width := 0
+	for _, bm := range benches {
+		for _, a := range routers {
+			if bm.supports(a) {
+				width = max(width, len(benchName(bm, a, bm.procs()[len(bm.procs())-1])))
+			}
+		}
+	}
+
+	rs := newResultSet()
+	fmt.Printf("goos: %s\ngoarch: %s\n", runtime.GOOS, runtime.GOARCH)
+	for _, bm := range benches {
+		for _, a := range routers {
+			if !bm.supports(a) {
+				continue
+			}
+			for _, procs := range bm.procs() {
+				for i := 0; i < count; i++ {
+					r := testing.Benchmark(func(b *testing.B) {
+						bm.runAt(b, a, procs)
+					})
+					fmt.Printf("%-*s\t%s\t%s\n", width, benchName(bm, a, procs), r.String(), r.MemString())
+
+					res := newResult(bm, a, r)
+					res.Procs = procs
+					res.Routes = 1
+					if bm.api != "" {
+						res.Routes = len(findAPI(bm.api).routes)
+						res.setMem(mem[bm.api][a.Name()])
+					}
+					rs.Results = append(rs.Results, res)
+				}
+			}
+		}
+	}
+	return rs
+}
+
+// benchName returns the name go test reports for the router's sub-benchmark
+// at the GOMAXPROCS level procs.
+func benchName(bm benchmark, a Adapter, procs int) string {
+	name := "Benchmark" + bm.name + "/" + a.Name()
+	if procs > 0 {
+		name += fmt.Sprintf("/procs=%d", procs)
+	}
+	return name
+}
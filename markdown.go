@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// writeMarkdown writes the results as the markdown tables of the README: the
+// benchmark system, the memory consumption per API, the micro benchmarks and
+// the timings of the benchmarks of each API. The best 3 values of each
+// column are bold. If a benchmark was run more than once, the median is
+// shown.
+func writeMarkdown(w io.Writer, rs *resultSet) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "## Results")
+	fmt.Fprintln(bw)
+	fmt.Fprintln(bw, "Benchmark System:")
+	fmt.Fprintln(bw)
+	if rs.CPU != "" {
+		fmt.Fprintf(bw, "- %s, NumCPU=%d\n", rs.CPU, rs.NumCPU)
+	} else {
+		fmt.Fprintf(bw, "- NumCPU=%d\n", rs.NumCPU)
+	}
+	fmt.Fprintf(bw, "- go version %s %s/%s, GOMAXPROCS=%d\n", rs.GoVersion, rs.GOOS, rs.GOARCH, rs.GOMAXPROCS)
+	fmt.Fprintf(bw, "- %s\n", rs.Date.Format("2006-01-02"))
+
+	routers := resultRouters(rs)
+	apiNames, micro, apiBenchmarks := resultBenchmarks(rs)
+
+	if len(apiNames) > 0 {
+		fmt.Fprintln(bw)
+		fmt.Fprintln(bw, "### Memory Consumption")
+		fmt.Fprintln(bw)
+		fmt.Fprintln(bw, "The memory required only for loading the routing structure for the respective API.")
+		fmt.Fprintln(bw)
+		writeTable(bw, routers, apiNames, "%.0f B", func(router, api string) (float64, bool) {
+			for _, r := range rs.Results {
+				if r.Router == router && r.API == api && r.HeapBytes > 0 {
+					return float64(r.HeapBytes), true
+				}
+			}
+			return 0, false
+		})
+	}
+
+	nsPerOp := func(router, bm string) (float64, bool) {
+		var samples []float64
+		for _, r := range rs.Results {
+			if r.Router == router && r.name() == bm {
+				samples = append(samples, r.NsPerOp)
+			}
+		}
+		if len(samples) == 0 {
+			return 0, false
+		}
+		return median(samples), true
+	}
+
+	if len(micro) > 0 {
+		fmt.Fprintln(bw)
+		fmt.Fprintln(bw, "### Micro Benchmarks")
+		fmt.Fprintln(bw)
+		fmt.Fprintln(bw, "Time in ns per request, with only a single route loaded.")
+		fmt.Fprintln(bw)
+		writeTable(bw, routers, micro, "%s", nsPerOp)
+	}
+
+	for _, api := range apiNames {
+		fmt.Fprintln(bw)
+		fmt.Fprintln(bw, "### "+api)
+		fmt.Fprintln(bw)
+		fmt.Fprintln(bw, "Time in ns per operation, with all routes of the API loaded.")
+		fmt.Fprintln(bw)
+		writeTable(bw, routers, apiBenchmarks[api], "%s", nsPerOp)
+	}
+
+	return bw.Flush()
+}
+
+// resultRouters returns the routers in the order of their first result.
+func resultRouters(rs *resultSet) []string {
+	var routers []string
+	for _, r := range rs.Results {
+		if !slices.Contains(routers, r.Router) {
+			routers = append(routers, r.Router)
+		}
+	}
+	return routers
+}
+
+// resultBenchmarks returns the APIs, the micro benchmarks and the benchmarks
+// of each API in the order of their first result.
+func resultBenchmarks(rs *resultSet) (apiNames, micro []string, apiBenchmarks map[string][]string) {
+	apiBenchmarks = make(map[string][]string)
+	for _, r := range rs.Results {
+		if r.API == "" {
+			if !slices.Contains(micro, r.name()) {
+				micro = append(micro, r.name())
+			}
+			continue
+		}
+		if !slices.Contains(apiNames, r.API) {
+			apiNames = append(apiNames, r.API)
+		}
+		if !slices.Contains(apiBenchmarks[r.API], r.name()) {
+			apiBenchmarks[r.API] = append(apiBenchmarks[r.API], r.name())
+		}
+	}
+	return apiNames, micro, apiBenchmarks
+}
+
+// writeTable writes a table with a row per router and a column per entry of
+// columns. Values are formatted with format, where %s formats like go test
+// does. Missing values are shown as "-", the 3 lowest values of each column
+// are bold.
+func writeTable(w io.Writer, routers, columns []string, format string, value func(router, column string) (float64, bool)) {
+	cells := make([][]string, len(routers))
+	for i := range cells {
+		cells[i] = make([]string, len(columns))
+	}
+	for j, column := range columns {
+		var values []float64
+		for _, router := range routers {
+			if v, ok := value(router, column); ok {
+				values = append(values, v)
+			}
+		}
+		best := topThree(values)
+		for i, router := range routers {
+			v, ok := value(router, column)
+			switch {
+			case !ok:
+				cells[i][j] = "-"
+			case v <= best:
+				cells[i][j] = "**" + formatValue(format, v) + "**"
+			default:
+				cells[i][j] = formatValue(format, v)
+			}
+		}
+	}
+
+	widths := make([]int, len(columns)+1)
+	widths[0] = len("Router")
+	for _, router := range routers {
+		widths[0] = max(widths[0], len(router))
+	}
+	for j, column := range columns {
+		widths[j+1] = max(len(column), 2)
+		for i := range routers {
+			widths[j+1] = max(widths[j+1], len(cells[i][j]))
+		}
+	}
+
+	row := func(first string, rest []string, right bool) {
+		fmt.Fprintf(w, "| %-*s |", widths[0], first)
+		for j, s := range rest {
+			if right {
+				fmt.Fprintf(w, " %*s |", widths[j+1], s)
+			} else {
+				fmt.Fprintf(w, " %-*s |", widths[j+1], s)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	row("Router", columns, true)
+	sep := make([]string, len(columns))
+	for j := range columns {
+		sep[j] = strings.Repeat("-", widths[j+1]-1) + ":"
+	}
+	row(":"+strings.Repeat("-", widths[0]-1), sep, false)
+	for i, router := range routers {
+		row(router, cells[i], true)
+	}
+}
+
+// topThree returns the third lowest distinct value, or the highest if there
+// are less than 3 different values.
+func topThree(values []float64) float64 {
+	values = slices.Clone(values)
+	slices.Sort(values)
+	values = slices.Compact(values)
+	if len(values) == 0 {
+		return 0
+	}
+	return values[min(2, len(values)-1)]
+}
+
+// formatValue formats v with format. The verb %s formats a time in ns like
+// go test, with less precision for larger values.
+func formatValue(format string, v float64) string {
+	if format != "%s" {
+		return fmt.Sprintf(format, v)
+	}
+	prec := 0
+	switch {
+	case v < 10:
+		prec = 2
+	case v < 100:
+		prec = 1
+	}
+	return strconv.FormatFloat(v, 'f', prec, 64)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownTable(t *testing.T) {
+	values := map[string]float64{
+		"A": 120, "B": 4.5, "C": 80, "D": 1000,
+	}
+	var buf bytes.Buffer
+	writeTable(&buf, []string{"A", "B", "C", "D", "E"}, []string{"All"}, "%s", func(router, _ string) (float64, bool) {
+		v, ok := values[router]
+		return v, ok
+	})
+
+	expected := strings.Join([]string{
+		"| Router |      All |",
+		"| :----- | -------: |",
+		"| A      |  **120** |",
+		"| B      | **4.50** |",
+		"| C      | **80.0** |",
+		"| D      |     1000 |",
+		"| E      |        - |",
+		"",
+	}, "\n")
+	if buf.String() != expected {
+		t.Errorf("table:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestMarkdownMedian(t *testing.T) {
+	rs := &resultSet{
+		GoVersion: "go1.24",
+		Results: []result{
+			{Benchmark: "Param", Router: "HttpRouter", NsPerOp: 30},
+			{Benchmark: "Param", Router: "HttpRouter", NsPerOp: 10},
+			{Benchmark: "Param", Router: "HttpRouter", NsPerOp: 20},
+		},
+	}
+	var buf bytes.Buffer
+	if err := writeMarkdown(&buf, rs); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "| HttpRouter | **20.0** |") {
+		t.Errorf("expected the median in the micro benchmarks table:\n%s", buf.String())
+	}
+}
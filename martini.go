@@ -0,0 +1,169 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/go-martini/martini"
+)
+
+func init() {
+	initMartini()
+	register(martiniAdapter{})
+}
+
+type martiniAdapter struct{}
+
+func (martiniAdapter) Name() string { return "Martini" }
+func (martiniAdapter) Features() Feature {
+	return FeatureParams | FeatureWildcard | FeatureMixedSegments |
+		FeatureMiddleware | FeatureAdd | FeatureConcurrentAdd | FeatureReverse
+}
+
+func (martiniAdapter) Load(routes []route) http.Handler {
+	return loadMartini(routes)
+}
+
+func (martiniAdapter) Reverse(router http.Handler) reverseFunc {
+	r := martiniRouter(router)
+	return func(name string, pairs []string) string {
+		values := make([]interface{}, 0, len(pairs)/2)
+		for i := 1; i < len(pairs); i += 2 {
+			values = append(values, pairs[i])
+		}
+		return r.URLFor(name, values...)
+	}
+}
+
+func (martiniAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	var h interface{} = martiniHandler
+	if write {
+		h = martiniHandlerWrite
+		if wildcardRe.MatchString(path) {
+			h = martiniHandlerWriteWildcard
+		}
+	}
+	path = wildcardRe.ReplaceAllString(path, "**")
+	return loadMartiniSingle(method, path, h)
+}
+
+func (martiniAdapter) Add(router http.Handler, r route) {
+	var h interface{} = martiniHandler
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+	martiniRouter(router).AddRoute(r.method, wildcardRe.ReplaceAllString(r.path, "**"), h)
+}
+
+func martiniHandler() {}
+
+func martiniHandlerWrite(params martini.Params) string {
+	return params["name"]
+}
+
+func martiniHandlerWriteWildcard(params martini.Params) string {
+	return params["_1"]
+}
+
+func initMartini() {
+	martini.Env = martini.Prod
+}
+
+func martiniHandlerParams(path string) func(http.ResponseWriter, martini.Params) {
+	return func(w http.ResponseWriter, params martini.Params) {
+		writeParams(w, path, func(name string, catchAll bool) string {
+			if catchAll {
+				return params["_1"]
+			}
+			return params[name]
+		})
+	}
+}
+
+func martiniMiddleware(c martini.Context) {
+	c.Next()
+}
+
+func martiniMiddlewareTest(c martini.Context) {
+	middlewareCalls.Add(1)
+	c.Next()
+}
+
+func loadMartini(routes []route) http.Handler {
+	var h interface{} = martiniHandler
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+
+	router := martini.NewRouter()
+	for i, route := range routes {
+		if loadParamsHandler {
+			h = martiniHandlerParams(route.path)
+		}
+
+		path := wildcardRe.ReplaceAllString(route.path, "**")
+
+		var r martini.Route
+		switch route.method {
+		case http.MethodGet:
+			r = router.Get(path, h)
+		case http.MethodPost:
+			r = router.Post(path, h)
+		case http.MethodPut:
+			r = router.Put(path, h)
+		case http.MethodPatch:
+			r = router.Patch(path, h)
+		case http.MethodDelete:
+			r = router.Delete(path, h)
+		default:
+			panic("Unknow HTTP method: " + route.method)
+		}
+		if loadRouteNames {
+			r.Name(routeName(i))
+		}
+	}
+	m := martini.New()
+	mw := martiniMiddleware
+	if loadTestHandler {
+		mw = martiniMiddlewareTest
+	}
+	for range loadMiddlewares {
+		m.Use(mw)
+	}
+	// The router for Reverse and Add
+	m.MapTo(router, (*martini.Router)(nil))
+	m.Action(router.Handle)
+	return m
+}
+
+// martiniRouter returns the router mapped into a Martini loaded by loadMartini.
+func martiniRouter(router http.Handler) martini.Router {
+	v := router.(*martini.Martini).Get(reflect.TypeOf((*martini.Router)(nil)).Elem())
+	return v.Interface().(martini.Router)
+}
+
+func loadMartiniSingle(method, path string, handler interface{}) http.Handler {
+	router := martini.NewRouter()
+	switch method {
+	case http.MethodGet:
+		router.Get(path, handler)
+	case http.MethodPost:
+		router.Post(path, handler)
+	case http.MethodPut:
+		router.Put(path, handler)
+	case http.MethodPatch:
+		router.Patch(path, handler)
+	case http.MethodDelete:
+		router.Delete(path, handler)
+	default:
+		panic("Unknow HTTP method: " + method)
+	}
+
+	martini := martini.New()
+	martini.Action(router.Handle)
+	return martini
+}
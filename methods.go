@@ -0,0 +1,99 @@
+package main
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// apiMethods are the methods used by the routes of the APIs.
+var apiMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// MethodsAdapter is implemented by the routers which only support some of
+// apiMethods. canServe leaves out the APIs with routes of other methods.
+type MethodsAdapter interface {
+	Adapter
+
+	// Methods returns the methods of apiMethods the router supports.
+	Methods() []string
+}
+
+// supportsMethod reports whether the router supports routes of the method.
+func supportsMethod(a Adapter, method string) bool {
+	ma, ok := a.(MethodsAdapter)
+	return !ok || slices.Contains(ma.Methods(), method)
+}
+
+// allowedMethods returns the methods of the routes matching path, in the
+// order of apiMethods.
+func allowedMethods(routes []route, path string) []string {
+	var allowed []string
+	for _, r := range routes {
+		if !slices.Contains(allowed, r.method) && matchesAny([]route{r}, path) {
+			allowed = append(allowed, r.method)
+		}
+	}
+	slices.SortFunc(allowed, func(a, b string) int {
+		return slices.Index(apiMethods, a) - slices.Index(apiMethods, b)
+	})
+	return allowed
+}
+
+// methodNotAllowedRoutes returns a request with a wrong method for the path
+// of each route, unless the path is routed for every method. Paths matched by
+// several route paths, like /users/new by /users/new and /users/:id, are left
+// out as well, the routers differ in which of them the Allow header covers.
+func methodNotAllowedRoutes(routes []route) []route {
+	var wrong []route
+	seen := make(map[string]bool)
+	for _, r := range requests(routes) {
+		if seen[r.path] || ambiguousPath(routes, r.path) {
+			continue
+		}
+		seen[r.path] = true
+		allowed := allowedMethods(routes, r.path)
+		for _, method := range apiMethods {
+			if !slices.Contains(allowed, method) {
+				wrong = append(wrong, route{method, r.path})
+				break
+			}
+		}
+	}
+	return wrong
+}
+
+// validAllow reports whether the Allow header lists exactly the allowed
+// methods, ignoring HEAD and OPTIONS which routers may add.
+func validAllow(header string, allowed []string) bool {
+	var methods []string
+	for _, m := range strings.Split(header, ",") {
+		m = strings.TrimSpace(m)
+		if m != "" && m != http.MethodHead && m != http.MethodOptions {
+			methods = append(methods, m)
+		}
+	}
+	slices.Sort(methods)
+	allowed = slices.Sorted(slices.Values(allowed))
+	return slices.Equal(methods, allowed)
+}
+
+// ambiguousPath reports whether path is matched by routes with different
+// paths.
+func ambiguousPath(routes []route, path string) bool {
+	matched := ""
+	for _, r := range routes {
+		if r.path != matched && matchesAny([]route{r}, path) {
+			if matched != "" {
+				return true
+			}
+			matched = r.path
+		}
+	}
+	return false
+}
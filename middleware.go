@@ -0,0 +1,77 @@
+package main
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+// middlewareCalls counts the calls of the middlewares loaded with
+// loadTestHandler, so the tests can check that the whole chain runs. The
+// benchmarked middlewares don't count.
+var middlewareCalls atomic.Int64
+
+// middleware is the trivial middleware in the standard form, which only calls
+// the next handler.
+func middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+	})
+}
+
+func middlewareTest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		middlewareCalls.Add(1)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// standardMiddleware returns the standard middleware to load, middlewareTest
+// if loadTestHandler is set.
+func standardMiddleware() func(http.Handler) http.Handler {
+	if loadTestHandler {
+		return middlewareTest
+	}
+	return middleware
+}
+
+// loadMiddlewareRouter returns the router with the given routes registered
+// behind a chain of n trivial middlewares. Routers with FeatureMiddleware
+// register them with their own middleware API, all others are wrapped in n
+// standard middlewares.
+func loadMiddlewareRouter(a Adapter, routes []route, n int) http.Handler {
+	loadMiddlewares = n
+	defer func() { loadMiddlewares = 0 }()
+
+	h := a.Load(routes)
+	if !a.Features().Has(FeatureMiddleware) {
+		mw := standardMiddleware()
+		for range n {
+			h = mw(h)
+		}
+	}
+	return h
+}
+
+// middlewareBenchmark routes all routes of the API once per operation, like
+// routesBenchmark, with each request passing a chain of n trivial
+// middlewares before the handler.
+func middlewareBenchmark(name, apiName string, n int) benchmark {
+	handlers := make(map[string]http.Handler)
+	return benchmark{
+		name: name,
+		api:  apiName,
+		supports: func(a Adapter) bool {
+			return canServe(a, findAPI(apiName).routes)
+		},
+		run: func(b *testing.B, a Adapter) {
+			api := findAPI(apiName)
+			h, ok := handlers[a.Name()]
+			if !ok {
+				h = loadMiddlewareRouter(a, api.routes, n)
+				handlers[a.Name()] = h
+			}
+			benchRoutes(b, h, requests(api.routes))
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRoutersMiddleware checks that the routers route the requests of the
+// GitHub API through the whole middleware chain, once per request.
+func TestRoutersMiddleware(t *testing.T) {
+	loadTestHandler = true
+	defer func() { loadTestHandler = false }()
+
+	for _, router := range adapters {
+		if !canServe(router, githubAPI) {
+			continue
+		}
+		t.Run(router.Name(), func(t *testing.T) {
+			for _, n := range []int{0, 1, 5} {
+				h := loadMiddlewareRouter(router, githubAPI, n)
+				middlewareCalls.Store(0)
+				reqs := requests(githubAPI)
+				for _, route := range reqs {
+					w := httptest.NewRecorder()
+					r, _ := http.NewRequest(route.method, route.path, nil)
+					r.RequestURI = route.path
+					h.ServeHTTP(w, r)
+					if w.Code != 200 || w.Body.String() != route.path {
+						t.Errorf("%d middlewares: %d - %s; expected %s %s", n, w.Code, w.Body.String(), route.method, route.path)
+					}
+				}
+				if calls := middlewareCalls.Load(); calls != int64(n*len(reqs)) {
+					t.Errorf("%d middlewares called %d times for %d requests", n, calls, len(reqs))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+// MutableAdapter is implemented by the routers accepting new routes after they
+// have been loaded, like an API gateway adding routes at runtime.
+type MutableAdapter interface {
+	Adapter
+
+	// Add registers the route with the router, which was returned by Load,
+	// using the same handler as Load.
+	Add(router http.Handler, r route)
+}
+
+// RemoveAdapter is implemented by the mutable routers which can also remove
+// routes again.
+type RemoveAdapter interface {
+	MutableAdapter
+
+	// Remove removes the route, which was registered by Load or Add, from
+	// the router.
+	Remove(router http.Handler, r route)
+}
+
+// addSafety returns whether routes can be added to the router while it serves
+// requests: "no" if it doesn't support adding routes, otherwise "safe" or
+// "unsafe", see FeatureConcurrentAdd.
+func addSafety(a Adapter) string {
+	switch f := a.Features(); {
+	case !f.Has(FeatureAdd):
+		return "no"
+	case f.Has(FeatureConcurrentAdd):
+		return "safe"
+	}
+	return "unsafe"
+}
+
+// mutationRoutes returns the routes added to a router loaded with routes: the
+// same routes under a second API version, so none of them conflicts with a
+// loaded route.
+func mutationRoutes(routes []route) []route {
+	rs := make([]route, len(routes))
+	for i, r := range routes {
+		rs[i] = route{r.method, "/v2" + r.path}
+	}
+	return rs
+}
+
+// addBenchmark adds one route of the second version of the API to a router
+// loaded with the API per operation, see mutationRoutes. Once all of them are
+// added, a fresh router is loaded with the timer stopped.
+func addBenchmark(name, apiName string) benchmark {
+	return benchmark{
+		name: name,
+		api:  apiName,
+		supports: func(a Adapter) bool {
+			_, ok := a.(MutableAdapter)
+			return ok && canServe(a, findAPI(apiName).routes)
+		},
+		run: func(b *testing.B, a Adapter) {
+			ma := a.(MutableAdapter)
+			routes := findAPI(apiName).routes
+			added := mutationRoutes(routes)
+			router := ma.Load(routes)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i, j := 0, 0; i < b.N; i, j = i+1, j+1 {
+				if j == len(added) {
+					b.StopTimer()
+					router = ma.Load(routes)
+					j = 0
+					b.StartTimer()
+				}
+				ma.Add(router, added[j])
+			}
+		},
+	}
+}
+
+// removeBenchmark removes one route of the second version of the API from a
+// router loaded with both versions per operation. Once all of them are
+// removed, they are added again with the timer stopped.
+func removeBenchmark(name, apiName string) benchmark {
+	return benchmark{
+		name: name,
+		api:  apiName,
+		supports: func(a Adapter) bool {
+			_, ok := a.(RemoveAdapter)
+			return ok && canServe(a, findAPI(apiName).routes)
+		},
+		run: func(b *testing.B, a Adapter) {
+			ra := a.(RemoveAdapter)
+			routes := findAPI(apiName).routes
+			added := mutationRoutes(routes)
+			router := ra.Load(routes)
+			for _, r := range added {
+				ra.Add(router, r)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i, j := 0, 0; i < b.N; i, j = i+1, j+1 {
+				if j == len(added) {
+					b.StopTimer()
+					for _, r := range added {
+						ra.Add(router, r)
+					}
+					j = 0
+					b.StartTimer()
+				}
+				ra.Remove(router, added[j])
+			}
+		},
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// serveRoute requests the route and reports whether the test handler
+// responded with its path.
+func serveRoute(h http.Handler, r route) bool {
+	req, _ := http.NewRequest(r.method, r.path, nil)
+	req.RequestURI = r.path
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w.Code == 200 && w.Body.String() == r.path
+}
+
+// TestRoutersAdd checks that the routes added to a router loaded with the
+// GitHub API are served next to the loaded ones, and that removed routes are
+// no longer served.
+func TestRoutersAdd(t *testing.T) {
+	loadTestHandler = true
+	defer func() { loadTestHandler = false }()
+
+	api := findAPI("GitHub")
+	added := mutationRoutes(api.routes)
+	for _, router := range adapters {
+		t.Run(router.Name(), func(t *testing.T) {
+			ma, ok := router.(MutableAdapter)
+			if ok != router.Features().Has(FeatureAdd) {
+				t.Fatal("FeatureAdd doesn't match implementing MutableAdapter")
+			}
+			if !ok {
+				reason := unsupported(router, FeatureAdd)
+				if reason == "" {
+					t.Fatal("neither FeatureAdd nor a reason from Unsupported")
+				}
+				t.Skip(reason)
+			}
+			if !canServe(router, api.routes) {
+				t.Skip("can't serve the GitHub API")
+			}
+
+			h := ma.Load(api.routes)
+			for _, r := range added {
+				ma.Add(h, r)
+			}
+			for _, r := range append(requests(api.routes), requests(added)...) {
+				if !serveRoute(h, r) {
+					t.Errorf("%s %s not served after adding the routes", r.method, r.path)
+				}
+			}
+
+			ra, ok := router.(RemoveAdapter)
+			if !ok {
+				return
+			}
+			for _, r := range added {
+				ra.Remove(h, r)
+			}
+			for _, r := range requests(added) {
+				if serveRoute(h, r) {
+					t.Errorf("%s %s still served after removing the routes", r.method, r.path)
+				}
+			}
+			for _, r := range requests(api.routes) {
+				if !serveRoute(h, r) {
+					t.Errorf("%s %s not served after removing the added routes", r.method, r.path)
+				}
+			}
+		})
+	}
+}
+
+// addConcurrentEnv names the router a child process of
+// TestRoutersAddConcurrent adds routes to.
+const addConcurrentEnv = "BENCH_ADD_CONCURRENT"
+
+// TestRoutersAddConcurrent adds the routes of a second GitHub API version to
+// each mutable router while it serves requests from several goroutines. A
+// concurrent map write is a fatal error which can't be recovered, so every
+// router runs in a child process. Run it with -race to find data races.
+//
+// The test only fails if a router with FeatureConcurrentAdd races, panics or
+// serves wrong responses; unsafe routers turning safe are logged, since races
+// are nondeterministic and not detected without -race.
+func TestRoutersAddConcurrent(t *testing.T) {
+	if name := os.Getenv(addConcurrentEnv); name != "" {
+		for _, router := range adapters {
+			if router.Name() == name {
+				addConcurrent(t, router.(MutableAdapter))
+			}
+		}
+		return
+	}
+	if testing.Short() {
+		t.Skip("runs a process per router")
+	}
+
+	var safe, unsafe []string
+	for _, router := range adapters {
+		if !router.Features().Has(FeatureAdd) || !canServe(router, findAPI("GitHub").routes) {
+			continue
+		}
+		t.Run(router.Name(), func(t *testing.T) {
+			cmd := exec.Command(os.Args[0], "-test.run=^TestRoutersAddConcurrent$", "-test.count=1")
+			cmd.Env = append(os.Environ(), addConcurrentEnv+"="+router.Name())
+			out, err := cmd.CombinedOutput()
+
+			outcome := addOutcome(out, err)
+			if outcome == "safe" {
+				safe = append(safe, router.Name())
+			} else {
+				unsafe = append(unsafe, router.Name()+" ("+outcome+")")
+			}
+
+			declared := router.Features().Has(FeatureConcurrentAdd)
+			switch {
+			case outcome != "safe" && declared:
+				t.Errorf("%s with FeatureConcurrentAdd:\n%s", outcome, out)
+			case outcome == "safe" && !declared && raceEnabled:
+				t.Log("safe without FeatureConcurrentAdd")
+			}
+		})
+	}
+
+	// Without the race detector, routers with data races pass as well
+	if raceEnabled {
+		t.Logf("safe: %s", strings.Join(safe, ", "))
+	}
+	if len(unsafe) > 0 {
+		t.Logf("unsafe: %s", strings.Join(unsafe, ", "))
+	}
+}
+
+// addOutcome classifies the result of a child process of
+// TestRoutersAddConcurrent.
+func addOutcome(out []byte, err error) string {
+	switch {
+	case err == nil:
+		return "safe"
+	case bytes.Contains(out, []byte("WARNING: DATA RACE")):
+		return "races"
+	case bytes.Contains(out, []byte("panic: ")), bytes.Contains(out, []byte("fatal error: ")):
+		return "panics"
+	default:
+		return "fails"
+	}
+}
+
+// addConcurrent serves the GitHub API and the routes added so far from
+// several goroutines, while the routes of the second version are added.
+func addConcurrent(t *testing.T, ma MutableAdapter) {
+	const goroutines = 4
+
+	loadTestHandler = true
+	defer func() { loadTestHandler = false }()
+
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(max(4, runtime.NumCPU())))
+
+	api := findAPI("GitHub")
+	reqs := requests(api.routes)
+	added := mutationRoutes(api.routes)
+	addedReqs := requests(added)
+	h := ma.Load(api.routes)
+
+	// n is the number of added routes, it is stored after adding a route
+	var n atomic.Int32
+	var done atomic.Bool
+	var failures atomic.Int32
+	fail := func(r route) {
+		// Only report the first few mismatches
+		if failures.Add(1) <= 5 {
+			t.Errorf("%s %s not served while adding routes", r.method, r.path)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := g * len(reqs) / goroutines; !done.Load(); i++ {
+				if r := reqs[i%len(reqs)]; !serveRoute(h, r) {
+					fail(r)
+				}
+				if k := n.Load(); k > 0 {
+					if r := addedReqs[i%int(k)]; !serveRoute(h, r) {
+						fail(r)
+					}
+				}
+			}
+		}()
+	}
+
+	for i, r := range added {
+		ma.Add(h, r)
+		n.Store(int32(i + 1))
+		// Let the goroutines serve requests between the additions
+		runtime.Gosched()
+	}
+	done.Store(true)
+	wg.Wait()
+}
@@ -0,0 +1,7 @@
+//go:build !race
+
+package main
+
+// raceEnabled reports whether the tests run with the race detector, without
+// which routers only turn out unsafe if they panic or serve wrong responses.
+const raceEnabled = false
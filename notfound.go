@@ -0,0 +1,122 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+)
+
+// scannerPaths are commonly requested by vulnerability scanners, regardless
+// of the API a server implements.
+var scannerPaths = []string{
+	"/.env",
+	"/.git/config",
+	"/admin/config.php",
+	"/cgi-bin/test.cgi",
+	"/phpmyadmin/index.php",
+	"/wp-login.php",
+	"/xmlrpc.php",
+}
+
+// deviation describes how a router deviates from responding with 404 Not
+// Found to paths no route matches, or with 404 or 405 Method Not Allowed to
+// requests for a path with a wrong method, or from listing exactly the
+// methods of the routes matching the path in the Allow header.
+type deviation struct {
+	notFound    string
+	wrongMethod string
+	allow       string
+
+	// panics is set if the router panics, which excludes it from the
+	// benchmarks of these requests
+	panics bool
+}
+
+// DeviantAdapter is implemented by the routers with known deviations, which
+// TestRoutersNotFound and TestRoutersMethodNotAllowed log instead of failing.
+type DeviantAdapter interface {
+	Adapter
+
+	// Deviation returns how the router deviates.
+	Deviation() deviation
+}
+
+// deviationOf returns the known deviation of the router, see DeviantAdapter.
+func deviationOf(a Adapter) deviation {
+	if da, ok := a.(DeviantAdapter); ok {
+		return da.Deviation()
+	}
+	return deviation{}
+}
+
+// notFoundRoutes returns requests for paths which none of the routes match,
+// regardless of the method: near misses of the request of each route, see
+// paramRequest, with a typo in its last static segment or an extra segment,
+// and the paths of scanners.
+func notFoundRoutes(routes []route) []route {
+	var candidates []route
+	for _, r := range routes {
+		req, _ := paramRequest(r)
+		pattern := strings.Split(r.path[1:], "/")
+		segments := strings.Split(req.path[1:], "/")
+		// A catch-all parameter is the last segment, so the static segments
+		// of the pattern and the request line up
+		for i := len(pattern) - 1; i >= 0; i-- {
+			if s := pattern[i]; len(s) > 1 && s[0] != ':' && s[0] != '*' {
+				typo := append(segments[:i:i], s[:len(s)-1])
+				typo = append(typo, segments[i+1:]...)
+				candidates = append(candidates, route{r.method, "/" + strings.Join(typo, "/")})
+				break
+			}
+		}
+		// Paths with a trailing slash are handled as prefixes by some
+		// routers
+		if !strings.HasSuffix(req.path, "/") {
+			candidates = append(candidates, route{r.method, req.path + "/nope"})
+		}
+	}
+	for _, path := range scannerPaths {
+		candidates = append(candidates, route{http.MethodGet, path})
+	}
+
+	var notFound []route
+	seen := make(map[string]bool)
+	for _, c := range candidates {
+		if seen[c.path] || matchesAny(routes, c.path) {
+			continue
+		}
+		seen[c.path] = true
+		notFound = append(notFound, c)
+	}
+	return notFound
+}
+
+// matchesAny reports whether any of the routes matches path, with each
+// :param matching exactly one non-empty segment and a *param at the end
+// matching the remainder.
+func matchesAny(routes []route, path string) bool {
+	segments := strings.Split(path, "/")
+	for _, r := range routes {
+		if matchesSegments(strings.Split(r.path, "/"), segments) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesSegments(pattern, segments []string) bool {
+	if n := len(pattern); strings.HasPrefix(pattern[n-1], "*") {
+		if len(segments) < n {
+			return false
+		}
+		pattern, segments = pattern[:n-1], segments[:n-1]
+	}
+	if len(pattern) != len(segments) {
+		return false
+	}
+	for i, p := range pattern {
+		if p != segments[i] && !(strings.HasPrefix(p, ":") && segments[i] != "") {
+			return false
+		}
+	}
+	return true
+}
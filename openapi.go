@@ -0,0 +1,196 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIDocument holds the parts of an OpenAPI 3 or Swagger 2 document
+// needed for the routes.
+type openAPIDocument struct {
+	OpenAPI string `yaml:"openapi"`
+	Swagger string `yaml:"swagger"`
+
+	// BasePath is the path prefix of the routes in Swagger 2, the servers
+	// have it in OpenAPI 3
+	BasePath string `yaml:"basePath"`
+	Servers  []struct {
+		URL string `yaml:"url"`
+	} `yaml:"servers"`
+
+	// Paths is kept as a node to get the routes in the order of the document
+	Paths yaml.Node `yaml:"paths"`
+}
+
+// openAPIMethods maps the operations of a path item to the methods the
+// adapters support.
+var openAPIMethods = map[string]string{
+	"get":    http.MethodGet,
+	"post":   http.MethodPost,
+	"put":    http.MethodPut,
+	"patch":  http.MethodPatch,
+	"delete": http.MethodDelete,
+}
+
+// templateRe matches the path templates of OpenAPI, like {petId}.
+var templateRe = regexp.MustCompile(`\{([^}/]*)\}`)
+
+// loadOpenAPI reads the OpenAPI 3 or Swagger 2 document in JSON or YAML
+// format from the named file and returns its routes, see parseOpenAPI.
+func loadOpenAPI(name string) (routes []route, skipped []string, err error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	routes, skipped, err = parseOpenAPI(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return routes, skipped, nil
+}
+
+// parseOpenAPI returns the routes of the operations of an OpenAPI 3 or
+// Swagger 2 document, in JSON or YAML format. The path templates are
+// converted to parameters, a template has to be a whole segment. Parameter
+// names are reduced to letters, digits and underscores, and parameters at the
+// same position get the name of the first one, as most routers require.
+// The routes are ordered by sortMixedSegments. Operations with other methods
+// than GET, POST, PUT, PATCH and DELETE or with templates within a segment are
+// skipped, they are returned as "METHOD /path".
+func parseOpenAPI(data []byte) (routes []route, skipped []string, err error) {
+	var doc openAPIDocument
+	// JSON is YAML as well
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") && !strings.HasPrefix(doc.Swagger, "2.") {
+		return nil, nil, errors.New("neither an OpenAPI 3 nor a Swagger 2 document")
+	}
+	if doc.Paths.Kind != yaml.MappingNode {
+		return nil, nil, errors.New("no paths")
+	}
+
+	base := strings.TrimSuffix(doc.BasePath, "/")
+	if len(doc.Servers) > 0 {
+		if u, err := url.Parse(doc.Servers[0].URL); err == nil && !strings.Contains(u.Path, "{") {
+			base = strings.TrimSuffix(u.Path, "/")
+		}
+	}
+
+	names := make(map[string]string)
+	for i := 0; i+1 < len(doc.Paths.Content); i += 2 {
+		template, item := doc.Paths.Content[i].Value, doc.Paths.Content[i+1]
+		path, ok := openAPIPath(base+template, names)
+		for j := 0; item.Kind == yaml.MappingNode && j+1 < len(item.Content); j += 2 {
+			key := item.Content[j].Value
+			method, known := openAPIMethods[key]
+			switch {
+			case known && ok:
+				routes = append(routes, route{method, path})
+			case known || key == "head" || key == "options" || key == "trace":
+				skipped = append(skipped, strings.ToUpper(key)+" "+base+template)
+			}
+		}
+	}
+	if len(routes) == 0 {
+		return nil, skipped, errors.New("no routes")
+	}
+	sortMixedSegments(routes)
+	return routes, skipped, nil
+}
+
+// openAPIPath converts the path templates to parameters. names holds the
+// parameter names by the path before them, to give parameters at the same
+// position the same name. It reports false if a template is not a whole
+// segment.
+func openAPIPath(template string, names map[string]string) (string, bool) {
+	segments := strings.Split(template, "/")
+	for i, segment := range segments {
+		if !strings.Contains(segment, "{") {
+			continue
+		}
+		m := templateRe.FindStringSubmatch(segment)
+		if m == nil || m[0] != segment {
+			return "", false
+		}
+		prefix := strings.Join(segments[:i], "/")
+		name, ok := names[prefix]
+		if !ok {
+			name = strings.Map(func(r rune) rune {
+				if r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+					return r
+				}
+				return '_'
+			}, m[1])
+			names[prefix] = name
+		}
+		segments[i] = ":" + name
+	}
+	return strings.Join(segments, "/"), true
+}
+
+// openAPIs are the names of the APIs added by addOpenAPI.
+var openAPIs []string
+
+// addOpenAPIFiles adds the OpenAPI or Swagger documents in the files as APIs,
+// see addOpenAPI, and reports the skipped operations on stderr.
+func addOpenAPIFiles(files []string) error {
+	for _, file := range files {
+		name, skipped, err := addOpenAPI(file)
+		if err != nil {
+			return err
+		}
+		for _, op := range skipped {
+			fmt.Fprintf(os.Stderr, "%s: skipped %s\n", name, op)
+		}
+	}
+	return nil
+}
+
+// addOpenAPI adds the routes of the OpenAPI or Swagger document in the named
+// file as an API named after the file, with the benchmarks of openAPIBenchmarks.
+// The skipped operations are returned.
+func addOpenAPI(file string) (name string, skipped []string, err error) {
+	routes, skipped, err := loadOpenAPI(file)
+	if err != nil {
+		return "", nil, err
+	}
+	name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	if name == "" {
+		return "", nil, fmt.Errorf("%s: no API name", file)
+	}
+	name = strings.ToUpper(name[:1]) + name[1:]
+	for _, api := range apis {
+		if strings.EqualFold(api.name, name) {
+			return "", nil, fmt.Errorf("%s: there is an API named %s already", file, api.name)
+		}
+	}
+
+	apis = append(apis, api{name, routes})
+	openAPIs = append(openAPIs, name)
+	benchmarks = append(benchmarks, openAPIBenchmarks(name)...)
+	return name, skipped, nil
+}
+
+// openAPIBenchmarks returns the benchmarks of an API loaded from an OpenAPI
+// document.
+func openAPIBenchmarks(apiName string) []benchmark {
+	return []benchmark{
+		routesBenchmark(apiName+"All", apiName),
+		zipfBenchmark(apiName+"Zipf", apiName),
+		parallelRoutesBenchmark(apiName+"AllParallel", apiName),
+		notFoundBenchmark(apiName+"NotFound", apiName),
+		methodNotAllowedBenchmark(apiName+"MethodNotAllowed", apiName),
+		loadBenchmark(apiName+"Load", apiName),
+	}
+}
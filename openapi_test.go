@@ -0,0 +1,131 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+const openAPI3Document = `
+openapi: 3.0.3
+info:
+  title: Pets
+  version: "1"
+servers:
+  - url: https://pets.example.com/v1/
+paths:
+  /pets:
+    summary: The pets
+    get: {}
+    post: {}
+    options: {}
+  /pets/{pet-id}:
+    parameters:
+      - name: pet-id
+        in: path
+    get: {}
+    delete: {}
+  /pets/{petId}/photos/{photo.id}:
+    put: {}
+  /pets/mine:
+    get: {}
+  /pets/{petId}/photo_{size}.png:
+    get: {}
+`
+
+const swagger2Document = `{
+  "swagger": "2.0",
+  "basePath": "/api",
+  "paths": {
+    "/users/{user}/repos": {"get": {}, "head": {}},
+    "/users/{login}": {"patch": {}}
+  }
+}`
+
+func TestParseOpenAPI(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		routes   []route
+		skipped  []string
+	}{
+		{
+			name:     "OpenAPI 3 YAML",
+			document: openAPI3Document,
+			routes: []route{
+				{http.MethodGet, "/v1/pets"},
+				{http.MethodPost, "/v1/pets"},
+				{http.MethodGet, "/v1/pets/mine"},
+				{http.MethodGet, "/v1/pets/:pet_id"},
+				{http.MethodDelete, "/v1/pets/:pet_id"},
+				{http.MethodPut, "/v1/pets/:pet_id/photos/:photo_id"},
+			},
+			skipped: []string{
+				"OPTIONS /v1/pets",
+				"GET /v1/pets/{petId}/photo_{size}.png",
+			},
+		},
+		{
+			name:     "Swagger 2 JSON",
+			document: swagger2Document,
+			routes: []route{
+				{http.MethodPatch, "/api/users/:user"},
+				{http.MethodGet, "/api/users/:user/repos"},
+			},
+			skipped: []string{"HEAD /api/users/{user}/repos"},
+		},
+	}
+	for _, tt := range tests {
+		routes, skipped, err := parseOpenAPI([]byte(tt.document))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(routes, tt.routes) {
+			t.Errorf("%s: got routes %v, expected %v", tt.name, routes, tt.routes)
+		}
+		if !slices.Equal(skipped, tt.skipped) {
+			t.Errorf("%s: got skipped %q, expected %q", tt.name, skipped, tt.skipped)
+		}
+	}
+
+	for _, document := range []string{"", "openapi: 3.0.0\n", `{"swagger": "1.2", "paths": {"/": {"get": {}}}}`, "[}"} {
+		if routes, _, err := parseOpenAPI([]byte(document)); err == nil {
+			t.Errorf("%q: got routes %v, expected an error", document, routes)
+		}
+	}
+}
+
+func TestHasMixedSegments(t *testing.T) {
+	tests := []struct {
+		paths []string
+		mixed bool
+	}{
+		{[]string{"/users/new", "/users/:id"}, true},
+		{[]string{"/users/:id/repos", "/users/:name/starred", "/users/:id/*path"}, true},
+		{[]string{"/files/readme", "/files/*path"}, true},
+		{[]string{"/users", "/users/:id", "/users/:id/repos"}, false},
+		{[]string{"/users/:id", "/user/new"}, false},
+	}
+	for _, tt := range tests {
+		var routes []route
+		for _, path := range tt.paths {
+			routes = append(routes, route{http.MethodGet, path})
+		}
+		if mixed := hasMixedSegments(routes); mixed != tt.mixed {
+			t.Errorf("%v: got %v, expected %v", tt.paths, mixed, tt.mixed)
+		}
+	}
+}
+
+// All routes of each API loaded with -openapi, as sub-benchmarks
+func BenchmarkOpenAPI(b *testing.B) {
+	for _, bm := range benchmarks {
+		if slices.Contains(openAPIs, bm.api) {
+			b.Run(bm.name, func(b *testing.B) {
+				runBenchmark(b, bm.name)
+			})
+		}
+	}
+}
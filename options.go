@@ -0,0 +1,112 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+)
+
+// options are the flags shared by the command and go test, which add APIs and
+// benchmarks or change how the benchmarks run. Both entry points register them
+// with register and apply them with apply after parsing.
+type options struct {
+	// openapi are OpenAPI documents added as APIs, e.g.
+	// go test -bench=OpenAPI -openapi=petstore.yaml
+	openapi string
+
+	// replay are access logs replayed by the Replay benchmarks, e.g.
+	// go test -bench=Replay -replay=access.log
+	replay string
+
+	// The request streams of the Zipf benchmarks, e.g.
+	// go test -bench=Zipf -zipf=1.5 -seed=2
+	zipfExponent float64
+	zipfSeed     uint64
+
+	// latency records the latency of every request in the lookup
+	// benchmarks, e.g. go test -bench=GithubAll -latency
+	latency bool
+
+	// The end-to-end benchmarks, e.g. go test -bench=E2E -e2e -conns=16
+	e2e   bool
+	conns int
+
+	// The HTTP/2 benchmarks, e.g. go test -bench=H2C -h2c -streams=128
+	h2c     bool
+	streams int
+
+	// The ResponseWriters of the lookup benchmarks and the benchmarks of
+	// each writer mode, e.g. go test -bench=GithubAll -writer=nethttp or
+	// go test -bench=Writers -writers
+	writer  string
+	writers bool
+
+	// The scaling benchmarks, which load route sets of up to 100,000
+	// routes into every router, e.g. go test -bench=Scale -scale -sizes=10,1000
+	scale bool
+	sizes string
+}
+
+// opts are the options of the running command or test binary.
+var opts options
+
+// register defines the flags of the options in fs, with the current settings
+// as defaults.
+func (o *options) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.openapi, "openapi", "", "comma-separated OpenAPI 3 or Swagger 2 `files`, in JSON or YAML, to add as APIs")
+	fs.StringVar(&o.replay, "replay", "", "comma-separated access log `files`, in the Common or Combined Log Format or JSONL, to replay against the API matching most of their requests")
+	fs.Float64Var(&o.zipfExponent, "zipf", zipfTraffic.exponent, "draw the requests of the Zipf benchmarks by the Zipf distribution with exponent `s`, greater than 1, or uniformly if 0")
+	fs.Uint64Var(&o.zipfSeed, "seed", zipfTraffic.seed, "`seed` of the request streams of the Zipf benchmarks")
+	fs.BoolVar(&o.latency, "latency", false, "record the latency of every request in the lookup benchmarks and report its percentiles")
+	fs.BoolVar(&o.e2e, "e2e", false, "add end-to-end benchmarks of each API, e.g. GithubE2E, serving the routers on a loopback HTTP server")
+	fs.IntVar(&o.conns, "conns", e2eConns, "send the requests of the end-to-end benchmarks over `n` keep-alive connections")
+	fs.BoolVar(&o.h2c, "h2c", false, "add HTTP/2 end-to-end benchmarks of each API, e.g. GithubH2C, serving the routers with h2c on a loopback server")
+	fs.IntVar(&o.streams, "streams", h2cStreams, "send the requests of the HTTP/2 benchmarks over `n` concurrent streams")
+	fs.StringVar(&o.writer, "writer", selectedWriter.name, "pass ResponseWriters of `mode` discard, recorder, buffered or nethttp to the routers in the lookup benchmarks")
+	fs.BoolVar(&o.writers, "writers", false, "add variants of ParamWrite and of the All and MethodNotAllowed benchmarks for each writer mode, e.g. GithubAllNetHTTP")
+	fs.BoolVar(&o.scale, "scale", false, "run the scaling benchmarks with synthetic route sets, which take a while to load")
+	fs.StringVar(&o.sizes, "sizes", "", "comma-separated route set `sizes` of -scale (default 10 to 100000)")
+}
+
+// apply validates the options and applies them: it adds the APIs first, so the
+// added benchmarks cover them as well.
+func (o *options) apply() error {
+	latencyMode = o.latency
+	zipfTraffic.exponent, zipfTraffic.seed = o.zipfExponent, o.zipfSeed
+	if err := zipfTraffic.validate(); err != nil {
+		return err
+	}
+	if err := addOpenAPIFiles(splitNames(o.openapi)); err != nil {
+		return err
+	}
+	if err := addReplayFiles(splitNames(o.replay)); err != nil {
+		return err
+	}
+	if o.conns < 1 {
+		return fmt.Errorf("invalid number of connections %d", o.conns)
+	}
+	e2eConns = o.conns
+	if o.e2e {
+		addE2EBenchmarks()
+	}
+	if o.streams < 1 {
+		return fmt.Errorf("invalid number of streams %d", o.streams)
+	}
+	h2cStreams = o.streams
+	if o.h2c {
+		addH2CBenchmarks()
+	}
+	sizes, err := parseSizes(splitNames(o.sizes))
+	if err != nil {
+		return err
+	}
+	scaleSizes = sizes
+	mode, err := findWriterMode(o.writer)
+	if err != nil {
+		return err
+	}
+	selectedWriter = mode
+	if o.writers {
+		addWriterBenchmarks()
+	}
+	return nil
+}
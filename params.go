@@ -0,0 +1,49 @@
+package main
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// writeParams writes the path of the matched route followed by name=value
+// for each of its parameters, with the values returned by value. Some routers
+// include the slash before the remainder matched by a catch-all parameter,
+// which is written without it.
+func writeParams(w io.Writer, path string, value func(name string, catchAll bool) string) {
+	var b strings.Builder
+	b.WriteString(path)
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" || (segment[0] != ':' && segment[0] != '*') {
+			continue
+		}
+		catchAll := segment[0] == '*'
+		v := value(segment[1:], catchAll)
+		if catchAll {
+			v = strings.TrimPrefix(v, "/")
+		}
+		b.WriteString(" " + segment[1:] + "=" + v)
+	}
+	io.WriteString(w, b.String())
+}
+
+// paramRequest returns the request for the route with distinct values for its
+// parameters and the response writeParams writes for it.
+func paramRequest(r route) (req route, want string) {
+	segments := strings.Split(r.path, "/")
+	want = r.path
+	for i, segment := range segments {
+		var value string
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			value = segment[1:] + strconv.Itoa(i)
+		case strings.HasPrefix(segment, "*"):
+			value = wildcardValue
+		default:
+			continue
+		}
+		segments[i] = value
+		want += " " + segment[1:] + "=" + value
+	}
+	return route{r.method, strings.Join(segments, "/")}, want
+}
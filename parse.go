@@ -0,0 +1,55 @@
+// Copyright 2013 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"net/http"
+)
+
+// Parse
+// https://parse.com/docs/rest#summary
+var parseAPI = []route{
+	// Objects
+	{http.MethodPost, "/1/classes/:className"},
+	{http.MethodGet, "/1/classes/:className/:objectId"},
+	{http.MethodPut, "/1/classes/:className/:objectId"},
+	{http.MethodGet, "/1/classes/:className"},
+	{http.MethodDelete, "/1/classes/:className/:objectId"},
+
+	// Users
+	{http.MethodPost, "/1/users"},
+	{http.MethodGet, "/1/login"},
+	{http.MethodGet, "/1/users/:objectId"},
+	{http.MethodPut, "/1/users/:objectId"},
+	{http.MethodGet, "/1/users"},
+	{http.MethodDelete, "/1/users/:objectId"},
+	{http.MethodPost, "/1/requestPasswordReset"},
+
+	// Roles
+	{http.MethodPost, "/1/roles"},
+	{http.MethodGet, "/1/roles/:objectId"},
+	{http.MethodPut, "/1/roles/:objectId"},
+	{http.MethodGet, "/1/roles"},
+	{http.MethodDelete, "/1/roles/:objectId"},
+
+	// Files
+	{http.MethodPost, "/1/files/:fileName"},
+
+	// Analytics
+	{http.MethodPost, "/1/events/:eventName"},
+
+	// Push Notifications
+	{http.MethodPost, "/1/push"},
+
+	// Installations
+	{http.MethodPost, "/1/installations"},
+	{http.MethodGet, "/1/installations/:objectId"},
+	{http.MethodPut, "/1/installations/:objectId"},
+	{http.MethodGet, "/1/installations"},
+	{http.MethodDelete, "/1/installations/:objectId"},
+
+	// Cloud Functions
+	{http.MethodPost, "/1/functions"},
+}
@@ -0,0 +1,109 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/bmizerany/pat"
+)
+
+func init() {
+	register(patAdapter{})
+}
+
+type patAdapter struct{}
+
+func (patAdapter) Name() string { return "Pat" }
+func (patAdapter) Features() Feature {
+	return FeatureParams | FeatureMethodNotAllowed | FeatureMixedSegments | FeatureAdd
+}
+
+func (patAdapter) Unsupported(f Feature) string {
+	switch f {
+	case FeatureWildcard:
+		return "only prefixes ending in / match the remainder, which is not captured"
+	}
+	return ""
+}
+
+func (patAdapter) Load(routes []route) http.Handler {
+	return loadPat(routes)
+}
+
+func (patAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := http.HandlerFunc(httpHandlerFunc)
+	if write {
+		h = patHandlerWrite
+	}
+	return loadPatSingle(method, path, h)
+}
+
+func (patAdapter) Add(router http.Handler, r route) {
+	h := http.HandlerFunc(httpHandlerFunc)
+	if loadTestHandler {
+		h = http.HandlerFunc(httpHandlerFuncTest)
+	}
+	router.(*pat.PatternServeMux).Add(r.method, r.path, h)
+}
+
+func patHandlerWrite(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, r.URL.Query().Get(":name"))
+}
+
+func patHandlerParams(path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		writeParams(w, path, func(name string, _ bool) string {
+			return query.Get(":" + name)
+		})
+	}
+}
+
+func loadPat(routes []route) http.Handler {
+	h := http.HandlerFunc(httpHandlerFunc)
+	if loadTestHandler {
+		h = http.HandlerFunc(httpHandlerFuncTest)
+	}
+
+	m := pat.New()
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = patHandlerParams(route.path)
+		}
+
+		switch route.method {
+		case http.MethodGet:
+			m.Get(route.path, h)
+		case http.MethodPost:
+			m.Post(route.path, h)
+		case http.MethodPut:
+			m.Put(route.path, h)
+		case http.MethodDelete:
+			m.Del(route.path, h)
+		default:
+			panic("Unknow HTTP method: " + route.method)
+		}
+	}
+	return m
+}
+
+func loadPatSingle(method, path string, handler http.Handler) http.Handler {
+	m := pat.New()
+	switch method {
+	case http.MethodGet:
+		m.Get(path, handler)
+	case http.MethodPost:
+		m.Post(path, handler)
+	case http.MethodPut:
+		m.Put(path, handler)
+	case http.MethodDelete:
+		m.Del(path, handler)
+	default:
+		panic("Unknow HTTP method: " + method)
+	}
+	return m
+}
@@ -0,0 +1,91 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/vanng822/r2router"
+)
+
+func init() {
+	register(r2routerAdapter{})
+}
+
+type r2routerAdapter struct{}
+
+func (r2routerAdapter) Name() string { return "R2router" }
+func (r2routerAdapter) Features() Feature {
+	return FeatureParams | FeatureMixedSegments | FeatureAdd
+}
+
+func (r2routerAdapter) Unsupported(f Feature) string {
+	switch f {
+	case FeatureWildcard:
+		return "no catch-all parameters"
+	}
+	return ""
+}
+
+func (r2routerAdapter) Load(routes []route) http.Handler {
+	return loadR2router(routes)
+}
+
+func (r2routerAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := r2routerHandler
+	if write {
+		h = r2routerHandleWrite
+	}
+	return loadR2routerSingle(method, path, h)
+}
+
+func (r2routerAdapter) Add(router http.Handler, r route) {
+	h := r2routerHandler
+	if loadTestHandler {
+		h = r2routerHandleTest
+	}
+	router.(*r2router.Router).AddHandler(r.method, r.path, h)
+}
+
+func r2routerHandler(w http.ResponseWriter, req *http.Request, _ r2router.Params) {}
+
+func r2routerHandleWrite(w http.ResponseWriter, req *http.Request, params r2router.Params) {
+	io.WriteString(w, params.Get("name"))
+}
+
+func r2routerHandleTest(w http.ResponseWriter, req *http.Request, _ r2router.Params) {
+	io.WriteString(w, req.RequestURI)
+}
+
+func r2routerHandleParams(path string) r2router.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request, params r2router.Params) {
+		writeParams(w, path, func(name string, _ bool) string {
+			return params.Get(name)
+		})
+	}
+}
+
+func loadR2router(routes []route) http.Handler {
+	h := r2routerHandler
+	if loadTestHandler {
+		h = r2routerHandleTest
+	}
+
+	router := r2router.NewRouter()
+	for _, r := range routes {
+		if loadParamsHandler {
+			h = r2routerHandleParams(r.path)
+		}
+		router.AddHandler(r.method, r.path, h)
+	}
+	return router
+}
+
+func loadR2routerSingle(method, path string, handler r2router.HandlerFunc) http.Handler {
+	router := r2router.NewRouter()
+	router.AddHandler(method, path, handler)
+	return router
+}
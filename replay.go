@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// jsonLogEntry is a line of a JSONL access log. The request is either given
+// by the method and the path, URI or URL, or as the request line of the
+// Common Log Format.
+type jsonLogEntry struct {
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	URI     string `json:"uri"`
+	URL     string `json:"url"`
+	Request string `json:"request"`
+}
+
+// readAccessLog reads the requests of the access log in the named file, see
+// parseAccessLog.
+func readAccessLog(name string) (reqs []route, skipped int, err error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+	reqs, skipped, err = parseAccessLog(f)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %v", name, err)
+	}
+	return reqs, skipped, nil
+}
+
+// parseAccessLog returns the requests of an access log in the Common or
+// Combined Log Format or in JSONL, in the order of the log. The query of the
+// requests is dropped. Lines without a request with one of the apiMethods are
+// skipped and counted, like the 400 Bad Request lines of garbage requests.
+func parseAccessLog(r io.Reader) (reqs []route, skipped int, err error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+		if req, ok := parseLogLine(line); ok {
+			reqs = append(reqs, req)
+		} else {
+			skipped++
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, 0, err
+	}
+	if len(reqs) == 0 {
+		return nil, skipped, errors.New("no requests")
+	}
+	return reqs, skipped, nil
+}
+
+// parseLogLine returns the request of a line of the access log.
+func parseLogLine(line string) (route, bool) {
+	var method, target string
+	if strings.HasPrefix(line, "{") {
+		var e jsonLogEntry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			return route{}, false
+		}
+		if e.Request != "" {
+			method, target = splitRequestLine(e.Request)
+		} else {
+			method = e.Method
+			target = cmp.Or(e.Path, e.URI, e.URL)
+		}
+	} else {
+		// The request line is the first quoted field:
+		// host ident user [time] "GET /path HTTP/1.1" status size ...
+		_, rest, ok := strings.Cut(line, `"`)
+		if !ok {
+			return route{}, false
+		}
+		request, _, ok := strings.Cut(rest, `"`)
+		if !ok {
+			return route{}, false
+		}
+		method, target = splitRequestLine(request)
+	}
+
+	method = strings.ToUpper(method)
+	if !slices.Contains(apiMethods, method) {
+		return route{}, false
+	}
+	u, err := url.Parse(target)
+	if err != nil || !strings.HasPrefix(u.Path, "/") {
+		return route{}, false
+	}
+	return route{method, u.Path}, true
+}
+
+// splitRequestLine returns the method and the target of a request line like
+// "GET /path HTTP/1.1".
+func splitRequestLine(line string) (method, target string) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return "", ""
+	}
+	return fields[0], fields[1]
+}
+
+// replayRequests returns the requests matched by one of the routes, with a
+// :param matching one non-empty segment and a *param the remainder.
+func replayRequests(routes []route, reqs []route) []route {
+	// Logs repeat the same requests a lot
+	matched := make(map[route]bool)
+	var replay []route
+	for _, req := range reqs {
+		ok, seen := matched[req]
+		if !seen {
+			segments := strings.Split(req.path, "/")
+			ok = slices.ContainsFunc(routes, func(r route) bool {
+				return r.method == req.method && matchesSegments(strings.Split(r.path, "/"), segments)
+			})
+			matched[req] = ok
+		}
+		if ok {
+			replay = append(replay, req)
+		}
+	}
+	return replay
+}
+
+// replayAPI returns the API whose routes match the most requests of the
+// access log and the matched requests.
+func replayAPI(reqs []route) (api, []route) {
+	var best api
+	var replay []route
+	for _, api := range apis {
+		if r := replayRequests(api.routes, reqs); len(r) > len(replay) {
+			best, replay = api, r
+		}
+	}
+	return best, replay
+}
+
+// replays are the names of the benchmarks added by addReplay.
+var replays []string
+
+// addReplayFiles adds a replay benchmark for each of the access logs, see
+// addReplay, and reports the requests replayed on stderr.
+func addReplayFiles(files []string) error {
+	for _, file := range files {
+		name, replayed, total, err := addReplay(file)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "%s: replaying %d of %d requests\n", name, replayed, total)
+	}
+	return nil
+}
+
+// addReplay reads the access log in the named file and adds a benchmark
+// replaying its requests matched by the API matching the most of them. The
+// benchmark is named after the API, e.g. GithubReplay. The number of
+// requests replayed and the number of lines are returned.
+func addReplay(file string) (name string, replayed, total int, err error) {
+	reqs, skipped, err := readAccessLog(file)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	api, replay := replayAPI(reqs)
+	if len(replay) == 0 {
+		return "", 0, 0, fmt.Errorf("%s: no API matches any of the %d requests", file, len(reqs))
+	}
+
+	name = benchPrefix(api.name) + "Replay"
+	if slices.ContainsFunc(benchmarks, func(bm benchmark) bool { return bm.name == name }) {
+		return "", 0, 0, fmt.Errorf("%s: there is a benchmark named %s already", file, name)
+	}
+
+	benchmarks = append(benchmarks, replayBenchmark(name, api.name, replay))
+	replays = append(replays, name)
+	return name, len(replay), len(reqs) + skipped, nil
+}
+
+// replayBenchmark routes one request of the access log per operation, in the
+// order of the log, and reports the percentiles of the request latency as
+// well, see benchLatency.
+func replayBenchmark(name, apiName string, reqs []route) benchmark {
+	return benchmark{
+		name: name,
+		api:  apiName,
+		supports: func(a Adapter) bool {
+			return canServe(a, findAPI(apiName).routes)
+		},
+		run: func(b *testing.B, a Adapter) {
+			benchLatency(b, apiHandler(findAPI(apiName), a), reqs, 1)
+		},
+	}
+}
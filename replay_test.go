@@ -0,0 +1,99 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const accessLog = `
+127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /user/repos?page=2 HTTP/1.1" 200 2326
+127.0.0.1 - - [10/Oct/2000:13:55:37 -0700] "POST /repos/julienschmidt/httprouter/issues HTTP/1.1" 201 512 "https://example.com/" "curl/8.0"
+127.0.0.1 - - [10/Oct/2000:13:55:38 -0700] "\x16\x03\x01" 400 0
+127.0.0.1 - - [10/Oct/2000:13:55:39 -0700] "OPTIONS * HTTP/1.1" 200 0
+{"time": "2000-10-10T13:55:40Z", "method": "get", "path": "/users/gordon"}
+{"uri": "/repos/gordon/go/pulls/12", "method": "PATCH"}
+{"url": "https://api.example.com/gists/1/star", "method": "PUT"}
+{"request": "DELETE /gists/1 HTTP/2.0", "status": 204}
+{"path": "/no/method"}
+not a log line
+`
+
+func TestParseAccessLog(t *testing.T) {
+	reqs, skipped, err := parseAccessLog(strings.NewReader(accessLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []route{
+		{http.MethodGet, "/user/repos"},
+		{http.MethodPost, "/repos/julienschmidt/httprouter/issues"},
+		{http.MethodGet, "/users/gordon"},
+		{http.MethodPatch, "/repos/gordon/go/pulls/12"},
+		{http.MethodPut, "/gists/1/star"},
+		{http.MethodDelete, "/gists/1"},
+	}
+	if !reflect.DeepEqual(reqs, want) {
+		t.Errorf("got %v, expected %v", reqs, want)
+	}
+	if skipped != 4 {
+		t.Errorf("got %d skipped lines, expected 4", skipped)
+	}
+
+	if _, _, err := parseAccessLog(strings.NewReader("garbage\n")); err == nil {
+		t.Error("expected an error for a log without requests")
+	}
+}
+
+func TestReplayAPI(t *testing.T) {
+	reqs, _, _ := parseAccessLog(strings.NewReader(accessLog))
+	reqs = append(reqs, route{http.MethodGet, "/wp-login.php"}, route{http.MethodGet, "/user/repos"})
+
+	api, replay := replayAPI(reqs)
+	if api.name != "GitHub" {
+		t.Fatalf("got API %s, expected GitHub", api.name)
+	}
+	// The GitHub API has no PATCH routes
+	want := slices.Concat(reqs[:3], reqs[4:6], reqs[7:])
+	if !reflect.DeepEqual(replay, want) {
+		t.Errorf("got %v, expected %v", replay, want)
+	}
+}
+
+// TestRoutersReplay checks that the routers route the requests of an access
+// log, with other parameter values than the routes.
+func TestRoutersReplay(t *testing.T) {
+	loadTestHandler = true
+	defer func() { loadTestHandler = false }()
+
+	reqs, _, _ := parseAccessLog(strings.NewReader(accessLog))
+	api, replay := replayAPI(reqs)
+	for _, router := range adapters {
+		if !canServe(router, api.routes) {
+			continue
+		}
+		h := router.Load(api.routes)
+		for _, route := range replay {
+			req, _ := http.NewRequest(route.method, route.path, nil)
+			req.RequestURI = route.path
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+			if w.Code != 200 || w.Body.String() != route.path {
+				t.Errorf("%s: %d - %s; expected %s %s", router.Name(), w.Code, w.Body.String(), route.method, route.path)
+			}
+		}
+	}
+}
+
+// The requests of each access log loaded with -replay, as sub-benchmarks.
+// Besides the mean time per request it reports the percentiles of the
+// latency.
+func BenchmarkReplay(b *testing.B) {
+	for _, name := range replays {
+		b.Run(name, func(b *testing.B) {
+			runBenchmark(b, name)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// result is the outcome of one benchmark for one router.
+type result struct {
+	Benchmark string `json:"benchmark"`
+	API       string `json:"api,omitempty"`
+	Router    string `json:"router"`
+
+	// Procs is the GOMAXPROCS level of parallel benchmarks, zero otherwise.
+	Procs int `json:"procs,omitempty"`
+
+	// Routes is the number of routes loaded into the router. HeapBytes and
+	// HeapObjects are the memory retained by the routing structure,
+	// LoadBytes and LoadAllocs all memory allocated while loading it. They
+	// are only measured for the API benchmarks and zero otherwise.
+	Routes      int    `json:"routes"`
+	HeapBytes   uint64 `json:"heap_bytes,omitempty"`
+	HeapObjects uint64 `json:"heap_objects,omitempty"`
+	LoadBytes   uint64 `json:"load_bytes,omitempty"`
+	LoadAllocs  uint64 `json:"load_allocs,omitempty"`
+
+	N           int     `json:"n"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	BytesPerOp  int64   `json:"bytes_per_op"`
+	AllocsPerOp int64   `json:"allocs_per_op"`
+
+	// P50Ns to P999Ns are the percentiles of the request latency in ns. They
+	// are only measured by the replay and Zipf benchmarks and in latency
+	// mode, and zero otherwise.
+	P50Ns  float64 `json:"p50_ns,omitempty"`
+	P90Ns  float64 `json:"p90_ns,omitempty"`
+	P99Ns  float64 `json:"p99_ns,omitempty"`
+	P999Ns float64 `json:"p999_ns,omitempty"`
+
+	// ReqPerSec is the throughput of the end-to-end benchmarks, zero for the
+	// others.
+	ReqPerSec float64 `json:"req_per_sec,omitempty"`
+}
+
+// newResult converts the result of testing.Benchmark.
+func newResult(bm benchmark, a Adapter, r testing.BenchmarkResult) result {
+	return result{
+		Benchmark:   bm.name,
+		API:         bm.api,
+		Router:      a.Name(),
+		N:           r.N,
+		NsPerOp:     float64(r.T.Nanoseconds()) / float64(r.N),
+		BytesPerOp:  r.AllocedBytesPerOp(),
+		AllocsPerOp: r.AllocsPerOp(),
+		P50Ns:       r.Extra["p50-ns"],
+		P90Ns:       r.Extra["p90-ns"],
+		P99Ns:       r.Extra["p99-ns"],
+		P999Ns:      r.Extra["p99.9-ns"],
+		ReqPerSec:   r.Extra["req/s"],
+	}
+}
+
+// name returns the name of the benchmark, including the GOMAXPROCS level of
+// parallel benchmarks.
+func (r *result) name() string {
+	if r.Procs > 0 {
+		return fmt.Sprintf("%s/procs=%d", r.Benchmark, r.Procs)
+	}
+	return r.Benchmark
+}
+
+// setMem sets the memory consumption of the routing structure.
+func (r *result) setMem(m memStats) {
+	r.HeapBytes = m.HeapBytes
+	r.HeapObjects = m.HeapObjects
+	r.LoadBytes = m.AllocBytes
+	r.LoadAllocs = m.Allocs
+}
+
+// resultSet holds the results of a run together with the system they were
+// measured on. Only the command collects them, by running the benchmarks with
+// testing.Benchmark; go test prints its results in the benchmark format only.
+type resultSet struct {
+	Date       time.Time `json:"date"`
+	CPU        string    `json:"cpu,omitempty"`
+	GoVersion  string    `json:"go_version"`
+	GOOS       string    `json:"goos"`
+	GOARCH     string    `json:"goarch"`
+	NumCPU     int       `json:"num_cpu"`
+	GOMAXPROCS int       `json:"gomaxprocs"`
+
+	Results []result `json:"results"`
+}
+
+func newResultSet() *resultSet {
+	return &resultSet{
+		Date:       time.Now().UTC(),
+		CPU:        cpuModel(),
+		GoVersion:  runtime.Version(),
+		GOOS:       runtime.GOOS,
+		GOARCH:     runtime.GOARCH,
+		NumCPU:     runtime.NumCPU(),
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+	}
+}
+
+// cpuModel returns the model name of the CPU, if the system reports it.
+func cpuModel() string {
+	data, err := os.ReadFile("/proc/cpuinfo")
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if key, value, ok := strings.Cut(line, ":"); ok && strings.TrimSpace(key) == "model name" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// WriteJSON writes the result set as an indented JSON document.
+func (rs *resultSet) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(rs)
+}
+
+// csvHeader are the column names of the CSV output.
+var csvHeader = []string{
+	"benchmark", "api", "router", "procs", "routes",
+	"heap_bytes", "heap_objects", "load_bytes", "load_allocs", "n", "ns_per_op", "bytes_per_op", "allocs_per_op",
+	"p50_ns", "p90_ns", "p99_ns", "p999_ns", "req_per_sec",
+}
+
+// WriteCSV writes a header and one line per result.
+func (rs *resultSet) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, r := range rs.Results {
+		err := cw.Write([]string{
+			r.Benchmark,
+			r.API,
+			r.Router,
+			strconv.Itoa(r.Procs),
+			strconv.Itoa(r.Routes),
+			strconv.FormatUint(r.HeapBytes, 10),
+			strconv.FormatUint(r.HeapObjects, 10),
+			strconv.FormatUint(r.LoadBytes, 10),
+			strconv.FormatUint(r.LoadAllocs, 10),
+			strconv.Itoa(r.N),
+			strconv.FormatFloat(r.NsPerOp, 'f', -1, 64),
+			strconv.FormatInt(r.BytesPerOp, 10),
+			strconv.FormatInt(r.AllocsPerOp, 10),
+			strconv.FormatFloat(r.P50Ns, 'f', -1, 64),
+			strconv.FormatFloat(r.P90Ns, 'f', -1, 64),
+			strconv.FormatFloat(r.P99Ns, 'f', -1, 64),
+			strconv.FormatFloat(r.P999Ns, 'f', -1, 64),
+			strconv.FormatFloat(r.ReqPerSec, 'f', -1, 64),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeFile creates the named file and writes to it using write.
+func writeFile(name string, write func(w io.Writer) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readResults reads a result set written by WriteJSON.
+func readResults(name string) (*resultSet, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rs := new(resultSet)
+	if err := json.NewDecoder(f).Decode(rs); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return rs, nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+var testResults = &resultSet{
+	GoVersion: "go1.24",
+	Results: []result{
+		{Benchmark: "Param", Router: "HttpRouter", Routes: 1, N: 1000, NsPerOp: 12.5, BytesPerOp: 32, AllocsPerOp: 1},
+		{Benchmark: "GithubAll", API: "GitHub", Router: "Gin", Routes: 203, HeapBytes: 58808, HeapObjects: 412, LoadBytes: 91560, LoadAllocs: 1630, N: 100, NsPerOp: 27582, BytesPerOp: 0, AllocsPerOp: 0, P50Ns: 120, P90Ns: 180, P99Ns: 251, P999Ns: 1023},
+		{Benchmark: "GithubAllParallel", API: "GitHub", Router: "Gin", Procs: 4, Routes: 203, HeapBytes: 58808, HeapObjects: 412, LoadBytes: 91560, LoadAllocs: 1630, N: 400, NsPerOp: 7213, BytesPerOp: 0, AllocsPerOp: 0},
+		{Benchmark: "GithubE2E", API: "GitHub", Router: "Gin", Procs: 2, Routes: 203, HeapBytes: 58808, HeapObjects: 412, LoadBytes: 91560, LoadAllocs: 1630, N: 2000, NsPerOp: 22620, BytesPerOp: 2104, AllocsPerOp: 19, P50Ns: 132479, P90Ns: 158591, P99Ns: 3178495, P999Ns: 5087231, ReqPerSec: 44209.5},
+	},
+}
+
+func TestResultsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testResults.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := "benchmark,api,router,procs,routes,heap_bytes,heap_objects,load_bytes,load_allocs,n,ns_per_op,bytes_per_op,allocs_per_op,p50_ns,p90_ns,p99_ns,p999_ns,req_per_sec\n" +
+		"Param,,HttpRouter,0,1,0,0,0,0,1000,12.5,32,1,0,0,0,0,0\n" +
+		"GithubAll,GitHub,Gin,0,203,58808,412,91560,1630,100,27582,0,0,120,180,251,1023,0\n" +
+		"GithubAllParallel,GitHub,Gin,4,203,58808,412,91560,1630,400,7213,0,0,0,0,0,0,0\n" +
+		"GithubE2E,GitHub,Gin,2,203,58808,412,91560,1630,2000,22620,2104,19,132479,158591,3178495,5087231,44209.5\n"
+	if buf.String() != expected {
+		t.Errorf("CSV output:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestResultsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testResults.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	rs := new(resultSet)
+	if err := json.Unmarshal(buf.Bytes(), rs); err != nil {
+		t.Fatal(err)
+	}
+	if len(rs.Results) != len(testResults.Results) || rs.Results[1] != testResults.Results[1] {
+		t.Errorf("JSON round trip: got %+v, expected %+v", rs.Results, testResults.Results)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ReverseAdapter is implemented by the routers building the paths of named
+// routes from the values of their parameters, known as reverse routing or URL
+// generation.
+type ReverseAdapter interface {
+	Adapter
+
+	// Reverse returns the function building the paths of the routes of the
+	// router, which was returned by Load with loadRouteNames set.
+	Reverse(router http.Handler) reverseFunc
+}
+
+// reverseFunc returns the path of the route with the given name, see
+// routeName, with the values of its parameters. pairs alternates the names of
+// the parameters, without the colon, and their values. It returns an empty
+// string if it can't build the path.
+type reverseFunc func(name string, pairs []string) string
+
+// routeName returns the name of the i-th route registered with
+// loadRouteNames set.
+func routeName(i int) string {
+	return "route" + strconv.Itoa(i)
+}
+
+// reverseRoute is a route with its name and the values of its parameters.
+type reverseRoute struct {
+	name  string
+	pairs []string
+
+	// req requests the path the route is expected to be reversed to
+	req route
+}
+
+// reverseRoutes returns the routes with named parameters to reverse, with
+// distinct values for their parameters. Routes with catch-all parameters are
+// left out.
+func reverseRoutes(routes []route) []reverseRoute {
+	var rs []reverseRoute
+	for i, r := range routes {
+		if !strings.Contains(r.path, ":") || strings.Contains(r.path, "*") {
+			continue
+		}
+		var pairs []string
+		segments := strings.Split(r.path, "/")
+		for j, segment := range segments {
+			if strings.HasPrefix(segment, ":") {
+				segments[j] = segment[1:] + strconv.Itoa(j)
+				pairs = append(pairs, segment[1:], segments[j])
+			}
+		}
+		rs = append(rs, reverseRoute{routeName(i), pairs, route{r.method, strings.Join(segments, "/")}})
+	}
+	return rs
+}
+
+// loadReverse returns the router with the routes registered under their
+// names and the function building their paths.
+func loadReverse(a ReverseAdapter, routes []route) (http.Handler, reverseFunc) {
+	loadRouteNames = true
+	defer func() { loadRouteNames = false }()
+
+	h := a.Load(routes)
+	return h, a.Reverse(h)
+}
+
+// reverseBenchmark builds the paths of all routes of the API with parameters
+// once per operation, see reverseRoutes.
+func reverseBenchmark(name, apiName string) benchmark {
+	reversers := make(map[string]reverseFunc)
+	return benchmark{
+		name: name,
+		api:  apiName,
+		supports: func(a Adapter) bool {
+			_, ok := a.(ReverseAdapter)
+			return ok && canServe(a, findAPI(apiName).routes)
+		},
+		run: func(b *testing.B, a Adapter) {
+			api := findAPI(apiName)
+			reverse, ok := reversers[a.Name()]
+			if !ok {
+				_, reverse = loadReverse(a.(ReverseAdapter), api.routes)
+				reversers[a.Name()] = reverse
+			}
+			rs := reverseRoutes(api.routes)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				for _, r := range rs {
+					reverse(r.name, r.pairs)
+				}
+			}
+		},
+	}
+}
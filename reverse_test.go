@@ -0,0 +1,68 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestReverseRoutes(t *testing.T) {
+	rs := reverseRoutes([]route{
+		{http.MethodGet, "/users"},
+		{http.MethodGet, "/users/:user/repos/:repo"},
+		{http.MethodGet, "/files/:user/*path"},
+	})
+	want := []reverseRoute{
+		{"route1", []string{"user", "user2", "repo", "repo4"}, route{http.MethodGet, "/users/user2/repos/repo4"}},
+	}
+	if !reflect.DeepEqual(rs, want) {
+		t.Errorf("got %v, expected %v", rs, want)
+	}
+}
+
+// TestRoutersReverse checks that the routers implementing ReverseAdapter build
+// the paths of the routes with parameters of the APIs, and that the paths
+// route to the routes.
+func TestRoutersReverse(t *testing.T) {
+	loadTestHandler = true
+	defer func() { loadTestHandler = false }()
+
+	for _, router := range adapters {
+		t.Run(router.Name(), func(t *testing.T) {
+			ra, ok := router.(ReverseAdapter)
+			if ok != router.Features().Has(FeatureReverse) {
+				t.Fatal("FeatureReverse doesn't match implementing ReverseAdapter")
+			}
+			if !ok {
+				if reason := unsupported(router, FeatureReverse); reason != "" {
+					t.Skip(reason)
+				}
+				t.Skip("no reverse routing")
+			}
+
+			for _, name := range []string{"GitHub", "GPlus", "Parse"} {
+				api := findAPI(name)
+				if !canServe(router, api.routes) {
+					continue
+				}
+				h, reverse := loadReverse(ra, api.routes)
+				for _, r := range reverseRoutes(api.routes) {
+					path := reverse(r.name, r.pairs)
+					if path != r.req.path {
+						t.Errorf("API %s: %s built %q, expected %q", api.name, r.name, path, r.req.path)
+						continue
+					}
+
+					req, _ := http.NewRequest(r.req.method, path, nil)
+					req.RequestURI = path
+					w := httptest.NewRecorder()
+					h.ServeHTTP(w, req)
+					if w.Code != 200 || w.Body.String() != path {
+						t.Errorf("API %s: %d - %s; expected %s %s", api.name, w.Code, w.Body.String(), r.req.method, path)
+					}
+				}
+			}
+		})
+	}
+}
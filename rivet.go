@@ -0,0 +1,104 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"net/http"
+
+	"github.com/typepress/rivet"
+)
+
+func init() {
+	register(rivetAdapter{})
+}
+
+type rivetAdapter struct{}
+
+func (rivetAdapter) Name() string { return "Rivet" }
+func (rivetAdapter) Features() Feature {
+	return FeatureParams | FeatureWildcard | FeatureMixedSegments | FeatureAdd
+}
+
+func (rivetAdapter) Deviation() deviation {
+	return deviation{
+		notFound:    "responds with 400 Bad Request",
+		wrongMethod: "responds with 400 Bad Request",
+	}
+}
+
+func (rivetAdapter) Load(routes []route) http.Handler {
+	return loadRivet(routes)
+}
+
+func (rivetAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	var h interface{} = rivetHandler
+	if write {
+		h = rivetHandlerWrite
+		if wildcardRe.MatchString(path) {
+			h = rivetHandlerWriteWildcard
+		}
+	}
+	path = wildcardRe.ReplaceAllString(path, "**")
+	return loadRivetSingle(method, path, h)
+}
+
+func (rivetAdapter) Add(router http.Handler, r route) {
+	var h interface{} = rivetHandler
+	if loadTestHandler {
+		h = rivetHandlerTest
+	}
+	router.(*rivet.Rivet).Handle(r.method, wildcardRe.ReplaceAllString(r.path, "**"), h)
+}
+
+func rivetHandler() {}
+
+func rivetHandlerWrite(c *rivet.Context) {
+	c.WriteString(c.Get("name"))
+}
+
+func rivetHandlerWriteWildcard(c *rivet.Context) {
+	c.WriteString(c.Get("**"))
+}
+
+func rivetHandlerTest(c *rivet.Context) {
+	c.WriteString(c.Req.RequestURI)
+}
+
+func rivetHandlerParams(path string) func(*rivet.Context) {
+	return func(c *rivet.Context) {
+		writeParams(c.Res, path, func(name string, catchAll bool) string {
+			if catchAll {
+				return c.Get("**")
+			}
+			return c.Get(name)
+		})
+	}
+}
+
+func loadRivet(routes []route) http.Handler {
+	var h interface{} = rivetHandler
+	if loadTestHandler {
+		h = rivetHandlerTest
+	}
+
+	router := rivet.New()
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = rivetHandlerParams(route.path)
+		}
+
+		path := wildcardRe.ReplaceAllString(route.path, "**")
+		router.Handle(route.method, path, h)
+	}
+	return router
+}
+
+func loadRivetSingle(method, path string, handler interface{}) http.Handler {
+	router := rivet.New()
+
+	router.Handle(method, path, handler)
+
+	return router
+}
@@ -5,58 +5,21 @@
 package main
 
 import (
-	"fmt"
 	"io"
 	"log"
 	"net/http"
-	"os"
 	"regexp"
 	"runtime"
-
-	// If you add new routers please:
-	// - Keep the benchmark functions etc. alphabetically sorted
-	// - Make a pull request (without benchmark results) at
-	//   https://github.com/wayneashleyberry/go-http-routing-benchmark
-
-	"github.com/ant0ine/go-json-rest/rest"
-	"github.com/astaxie/beego"
-	"github.com/astaxie/beego/context"
-	"github.com/bmizerany/pat"
-	"github.com/go-playground/lars"
-	"github.com/wayneashleyberry/superhttp"
-
-	// "github.com/daryl/zeus"
-
-	"github.com/dimfeld/httptreemux/v5"
-	"github.com/emicklei/go-restful"
-	"github.com/gin-gonic/gin"
-	"github.com/go-chi/chi/v5"
-	"github.com/go-martini/martini"
-	"github.com/go-zoo/bone"
-	"github.com/gocraft/web"
-	"github.com/gorilla/mux"
-	gowwwrouter "github.com/gowww/router"
-	"github.com/julienschmidt/httprouter"
-	"github.com/labstack/echo/v4"
-	"github.com/naoina/denco"
-	urlrouter "github.com/naoina/kocha-urlrouter"
-	_ "github.com/naoina/kocha-urlrouter/doublearray"
-	"github.com/pilu/traffic"
-	"github.com/plimble/ace"
-	"github.com/rcrowley/go-tigertonic"
-	vulcan "github.com/vulcand/route"
-
-	// "github.com/revel/pathtree"
-	// "github.com/revel/revel"
-	"github.com/typepress/rivet"
-	"github.com/ursiform/bear"
-	"github.com/vanng822/r2router"
-	goji "github.com/zenazn/goji/web"
-	gojiv2 "goji.io"
-	gojiv2pat "goji.io/pat"
-	"gopkg.in/macaron.v1"
+	"slices"
+	"strings"
 )
 
+// If you add new routers please:
+// - Add a file named after the router, which registers an Adapter in its
+//   init function
+// - Make a pull request (without benchmark results) at
+//   https://github.com/wayneashleyberry/go-http-routing-benchmark
+
 type route struct {
 	method string
 	path   string
@@ -83,1473 +46,208 @@ var nullLogger *log.Logger
 // flag indicating if the normal or the test handler should be loaded
 var loadTestHandler = false
 
-func init() {
-	// beego sets it to runtime.NumCPU()
-	// Currently none of the contesters does concurrent routing
-	runtime.GOMAXPROCS(1)
-
-	// makes logging 'webscale' (ignores them)
-	log.SetOutput(new(mockResponseWriter))
-	nullLogger = log.New(new(mockResponseWriter), "", 0)
-
-	initBeego()
-	initGin()
-	initMartini()
-	initTraffic()
-}
-
-// Common
-func httpHandlerFunc(_ http.ResponseWriter, _ *http.Request) {}
-
-func httpHandlerFuncTest(w http.ResponseWriter, r *http.Request) {
-	io.WriteString(w, r.RequestURI)
-}
-
-// Ace
-func aceHandle(_ *ace.C) {}
-
-func aceHandleWrite(c *ace.C) {
-	io.WriteString(c.Writer, c.Param("name"))
-}
-
-func aceHandleTest(c *ace.C) {
-	io.WriteString(c.Writer, c.Request.RequestURI)
-}
-
-func loadAce(routes []route) http.Handler {
-	h := []ace.HandlerFunc{aceHandle}
-	if loadTestHandler {
-		h = []ace.HandlerFunc{aceHandleTest}
-	}
-
-	router := ace.New()
-	for _, route := range routes {
-		router.Handle(route.method, route.path, h)
-	}
-	return router
-}
-
-func loadAceSingle(method, path string, handle ace.HandlerFunc) http.Handler {
-	router := ace.New()
-	router.Handle(method, path, []ace.HandlerFunc{handle})
-	return router
-}
-
-// bear
-func bearHandler(_ http.ResponseWriter, _ *http.Request, _ *bear.Context) {}
-
-func bearHandlerWrite(w http.ResponseWriter, _ *http.Request, ctx *bear.Context) {
-	io.WriteString(w, ctx.Params["name"])
-}
-
-func bearHandlerTest(w http.ResponseWriter, r *http.Request, _ *bear.Context) {
-	io.WriteString(w, r.RequestURI)
-}
+// flag indicating if the handlers writing the matched route and its
+// parameters should be loaded, see writeParams
+var loadParamsHandler = false
 
-func loadBear(routes []route) http.Handler {
-	h := bearHandler
-	if loadTestHandler {
-		h = bearHandlerTest
-	}
+// number of trivial middlewares the routers with FeatureMiddleware register
+// with their own middleware API, see loadMiddlewareRouter
+var loadMiddlewares = 0
 
-	router := bear.New()
-	re := regexp.MustCompile(":([^/]*)")
-	for _, route := range routes {
-		switch route.method {
-		case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
-			router.On(route.method, re.ReplaceAllString(route.path, "{$1}"), h)
-		default:
-			panic("Unknown HTTP method: " + route.method)
-		}
-	}
-	return router
-}
+// flag indicating if the routers implementing ReverseAdapter should register
+// the routes under their names, see routeName
+var loadRouteNames = false
 
-func loadBearSingle(method string, path string, handler bear.HandlerFunc) http.Handler {
-	router := bear.New()
-	switch method {
-	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
-		router.On(method, path, handler)
-	default:
-		panic("Unknown HTTP method: " + method)
-	}
-	return router
-}
-
-// beego
-func beegoHandler(ctx *context.Context) {}
+// paramRe matches the :name parameters of the route paths. Routers using
+// another syntax replace them with their own.
+var paramRe = regexp.MustCompile(":([^/]*)")
 
-func beegoHandlerWrite(ctx *context.Context) {
-	ctx.WriteString(ctx.Input.Param(":name"))
-}
-
-func beegoHandlerTest(ctx *context.Context) {
-	ctx.WriteString(ctx.Request.RequestURI)
-}
+// wildcardRe matches the *name catch-all parameter at the end of route paths,
+// which matches the remainder of the path. Routers using another syntax
+// replace it with their own.
+var wildcardRe = regexp.MustCompile(`\*([^/]*)$`)
 
-func initBeego() {
-	beego.BConfig.RunMode = beego.PROD
-	beego.BeeLogger.Close()
-}
-
-func loadBeego(routes []route) http.Handler {
-	h := beegoHandler
-	if loadTestHandler {
-		h = beegoHandlerTest
-	}
-
-	re := regexp.MustCompile(":([^/]*)")
-	app := beego.NewControllerRegister()
-	for _, route := range routes {
-		route.path = re.ReplaceAllString(route.path, ":$1")
-		switch route.method {
-		case http.MethodGet:
-			app.Get(route.path, h)
-		case http.MethodPost:
-			app.Post(route.path, h)
-		case http.MethodPut:
-			app.Put(route.path, h)
-		case http.MethodPatch:
-			app.Patch(route.path, h)
-		case http.MethodDelete:
-			app.Delete(route.path, h)
-		default:
-			panic("Unknow HTTP method: " + route.method)
-		}
-	}
-	return app
-}
-
-func loadBeegoSingle(method, path string, handler beego.FilterFunc) http.Handler {
-	app := beego.NewControllerRegister()
-	switch method {
-	case http.MethodGet:
-		app.Get(path, handler)
-	case http.MethodPost:
-		app.Post(path, handler)
-	case http.MethodPut:
-		app.Put(path, handler)
-	case http.MethodPatch:
-		app.Patch(path, handler)
-	case http.MethodDelete:
-		app.Delete(path, handler)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return app
-}
-
-// bone
-func boneHandlerWrite(rw http.ResponseWriter, req *http.Request) {
-	io.WriteString(rw, bone.GetValue(req, "name"))
-}
-
-func loadBone(routes []route) http.Handler {
-	h := http.HandlerFunc(httpHandlerFunc)
-	if loadTestHandler {
-		h = http.HandlerFunc(httpHandlerFuncTest)
-	}
-
-	router := bone.New()
-	for _, route := range routes {
-		switch route.method {
-		case http.MethodGet:
-			router.Get(route.path, h)
-		case http.MethodPost:
-			router.Post(route.path, h)
-		case http.MethodPut:
-			router.Put(route.path, h)
-		case http.MethodPatch:
-			router.Patch(route.path, h)
-		case http.MethodDelete:
-			router.Delete(route.path, h)
-		default:
-			panic("Unknow HTTP method: " + route.method)
-		}
-	}
-	return router
-}
-
-func loadBoneSingle(method, path string, handler http.Handler) http.Handler {
-	router := bone.New()
-	switch method {
-	case http.MethodGet:
-		router.Get(path, handler)
-	case http.MethodPost:
-		router.Post(path, handler)
-	case http.MethodPut:
-		router.Put(path, handler)
-	case http.MethodPatch:
-		router.Patch(path, handler)
-	case http.MethodDelete:
-		router.Delete(path, handler)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return router
-}
-
-// chi
-func chiHandleWrite(w http.ResponseWriter, r *http.Request) {
-	io.WriteString(w, chi.URLParam(r, "name"))
-}
-
-func loadChi(routes []route) http.Handler {
-	h := httpHandlerFunc
-	if loadTestHandler {
-		h = httpHandlerFuncTest
-	}
-
-	re := regexp.MustCompile(":([^/]*)")
-
-	mux := chi.NewRouter()
-	for _, route := range routes {
-		path := re.ReplaceAllString(route.path, "{$1}")
-
-		switch route.method {
-		case http.MethodGet:
-			mux.Get(path, h)
-		case http.MethodPost:
-			mux.Post(path, h)
-		case http.MethodPut:
-			mux.Put(path, h)
-		case http.MethodPatch:
-			mux.Patch(path, h)
-		case http.MethodDelete:
-			mux.Delete(path, h)
-		default:
-			panic("Unknown HTTP method: " + route.method)
-		}
-	}
-	return mux
-}
-
-func loadChiSingle(method, path string, handler http.HandlerFunc) http.Handler {
-	mux := chi.NewRouter()
-	switch method {
-	case http.MethodGet:
-		mux.Get(path, handler)
-	case http.MethodPost:
-		mux.Post(path, handler)
-	case http.MethodPut:
-		mux.Put(path, handler)
-	case http.MethodPatch:
-		mux.Patch(path, handler)
-	case http.MethodDelete:
-		mux.Delete(path, handler)
-	default:
-		panic("Unknown HTTP method: " + method)
-	}
-	return mux
-}
-
-// superhttp
-func superhttpHandleWrite(w http.ResponseWriter, r *http.Request) {
-	io.WriteString(w, r.PathValue("name"))
-}
-
-func loadSuperhttp(routes []route) http.Handler {
-	h := httpHandlerFunc
-	if loadTestHandler {
-		h = httpHandlerFuncTest
-	}
-
-	re := regexp.MustCompile(":([^/]*)")
-
-	mux := superhttp.NewServeMux()
-	for _, route := range routes {
-		path := re.ReplaceAllString(route.path, "{$1}")
-
-		switch route.method {
-		case http.MethodGet:
-			mux.GET(path, h)
-		case http.MethodPost:
-			mux.POST(path, h)
-		case http.MethodPut:
-			mux.PUT(path, h)
-		case http.MethodPatch:
-			mux.PATCH(path, h)
-		case http.MethodDelete:
-			mux.DELETE(path, h)
-		default:
-			panic("Unknown HTTP method: " + route.method)
-		}
-	}
-	return mux
-}
-
-func loadSuperhttpSingle(method, path string, handler http.HandlerFunc) http.Handler {
-	mux := superhttp.NewServeMux()
-	switch method {
-	case http.MethodGet:
-		mux.GET(path, handler)
-	case http.MethodPost:
-		mux.POST(path, handler)
-	case http.MethodPut:
-		mux.PUT(path, handler)
-	case http.MethodPatch:
-		mux.PATCH(path, handler)
-	case http.MethodDelete:
-		mux.DELETE(path, handler)
-	default:
-		panic("Unknown HTTP method: " + method)
-	}
-	return mux
-}
-
-// Denco
-func dencoHandler(w http.ResponseWriter, r *http.Request, params denco.Params) {}
-
-func dencoHandlerWrite(w http.ResponseWriter, r *http.Request, params denco.Params) {
-	io.WriteString(w, params.Get("name"))
-}
-
-func dencoHandlerTest(w http.ResponseWriter, r *http.Request, params denco.Params) {
-	io.WriteString(w, r.RequestURI)
-}
-
-func loadDenco(routes []route) http.Handler {
-	h := dencoHandler
-	if loadTestHandler {
-		h = dencoHandlerTest
-	}
-
-	mux := denco.NewMux()
-	handlers := make([]denco.Handler, 0, len(routes))
-	for _, route := range routes {
-		handler := mux.Handler(route.method, route.path, h)
-		handlers = append(handlers, handler)
-	}
-	handler, err := mux.Build(handlers)
-	if err != nil {
-		panic(err)
-	}
-	return handler
-}
-
-func loadDencoSingle(method, path string, h denco.HandlerFunc) http.Handler {
-	mux := denco.NewMux()
-	handler, err := mux.Build([]denco.Handler{mux.Handler(method, path, h)})
-	if err != nil {
-		panic(err)
-	}
-	return handler
-}
-
-// Echo
-func echoHandler(c echo.Context) error {
-	return nil
-}
-
-func echoHandlerWrite(c echo.Context) error {
-	io.WriteString(c.Response(), c.Param("name"))
-	return nil
-}
-
-func echoHandlerTest(c echo.Context) error {
-	io.WriteString(c.Response(), c.Request().RequestURI)
-	return nil
-}
-
-func loadEcho(routes []route) http.Handler {
-	var h echo.HandlerFunc = echoHandler
-	if loadTestHandler {
-		h = echoHandlerTest
-	}
-
-	e := echo.New()
-	for _, r := range routes {
-		switch r.method {
-		case http.MethodGet:
-			e.GET(r.path, h)
-		case http.MethodPost:
-			e.POST(r.path, h)
-		case http.MethodPut:
-			e.PUT(r.path, h)
-		case http.MethodPatch:
-			e.PATCH(r.path, h)
-		case http.MethodDelete:
-			e.DELETE(r.path, h)
-		default:
-			panic("Unknow HTTP method: " + r.method)
-		}
-	}
-	return e
-}
-
-func loadEchoSingle(method, path string, h echo.HandlerFunc) http.Handler {
-	e := echo.New()
-	switch method {
-	case http.MethodGet:
-		e.GET(path, h)
-	case http.MethodPost:
-		e.POST(path, h)
-	case http.MethodPut:
-		e.PUT(path, h)
-	case http.MethodPatch:
-		e.PATCH(path, h)
-	case http.MethodDelete:
-		e.DELETE(path, h)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return e
-}
-
-// Gin
-func ginHandle(_ *gin.Context) {}
-
-func ginHandleWrite(c *gin.Context) {
-	io.WriteString(c.Writer, c.Params.ByName("name"))
-}
-
-func ginHandleTest(c *gin.Context) {
-	io.WriteString(c.Writer, c.Request.RequestURI)
-}
-
-func initGin() {
-	gin.SetMode(gin.ReleaseMode)
-}
-
-func loadGin(routes []route) http.Handler {
-	h := ginHandle
-	if loadTestHandler {
-		h = ginHandleTest
-	}
-
-	router := gin.New()
-	for _, route := range routes {
-		router.Handle(route.method, route.path, h)
-	}
-	return router
-}
-
-func loadGinSingle(method, path string, handle gin.HandlerFunc) http.Handler {
-	router := gin.New()
-	router.Handle(method, path, handle)
-	return router
-}
-
-// gocraft/web
-type gocraftWebContext struct{}
-
-func gocraftWebHandler(w web.ResponseWriter, r *web.Request) {}
-
-func gocraftWebHandlerWrite(w web.ResponseWriter, r *web.Request) {
-	io.WriteString(w, r.PathParams["name"])
-}
-
-func gocraftWebHandlerTest(w web.ResponseWriter, r *web.Request) {
-	io.WriteString(w, r.RequestURI)
-}
-
-func loadGocraftWeb(routes []route) http.Handler {
-	h := gocraftWebHandler
-	if loadTestHandler {
-		h = gocraftWebHandlerTest
-	}
-
-	router := web.New(gocraftWebContext{})
-	for _, route := range routes {
-		switch route.method {
-		case http.MethodGet:
-			router.Get(route.path, h)
-		case http.MethodPost:
-			router.Post(route.path, h)
-		case http.MethodPut:
-			router.Put(route.path, h)
-		case http.MethodPatch:
-			router.Patch(route.path, h)
-		case http.MethodDelete:
-			router.Delete(route.path, h)
-		default:
-			panic("Unknow HTTP method: " + route.method)
-		}
-	}
-	return router
-}
-
-func loadGocraftWebSingle(method, path string, handler interface{}) http.Handler {
-	router := web.New(gocraftWebContext{})
-	switch method {
-	case http.MethodGet:
-		router.Get(path, handler)
-	case http.MethodPost:
-		router.Post(path, handler)
-	case http.MethodPut:
-		router.Put(path, handler)
-	case http.MethodPatch:
-		router.Patch(path, handler)
-	case http.MethodDelete:
-		router.Delete(path, handler)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return router
-}
-
-// goji
-func gojiFuncWrite(c goji.C, w http.ResponseWriter, r *http.Request) {
-	io.WriteString(w, c.URLParams["name"])
-}
-
-func loadGoji(routes []route) http.Handler {
-	h := httpHandlerFunc
-	if loadTestHandler {
-		h = httpHandlerFuncTest
-	}
-
-	mux := goji.New()
-	for _, route := range routes {
-		switch route.method {
-		case http.MethodGet:
-			mux.Get(route.path, h)
-		case http.MethodPost:
-			mux.Post(route.path, h)
-		case http.MethodPut:
-			mux.Put(route.path, h)
-		case http.MethodPatch:
-			mux.Patch(route.path, h)
-		case http.MethodDelete:
-			mux.Delete(route.path, h)
-		default:
-			panic("Unknown HTTP method: " + route.method)
-		}
-	}
-	return mux
-}
-
-func loadGojiSingle(method, path string, handler interface{}) http.Handler {
-	mux := goji.New()
-	switch method {
-	case http.MethodGet:
-		mux.Get(path, handler)
-	case http.MethodPost:
-		mux.Post(path, handler)
-	case http.MethodPut:
-		mux.Put(path, handler)
-	case http.MethodPatch:
-		mux.Patch(path, handler)
-	case http.MethodDelete:
-		mux.Delete(path, handler)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return mux
-}
-
-// goji v2 (github.com/goji/goji)
-func gojiv2Handler(w http.ResponseWriter, r *http.Request) {}
-
-func gojiv2HandlerWrite(w http.ResponseWriter, r *http.Request) {
-	io.WriteString(w, gojiv2pat.Param(r, "name"))
-}
-
-func gojiv2HandlerTest(w http.ResponseWriter, r *http.Request) {
-	io.WriteString(w, r.RequestURI)
-}
-
-func loadGojiv2(routes []route) http.Handler {
-	h := gojiv2Handler
-	if loadTestHandler {
-		h = gojiv2HandlerTest
-	}
-
-	mux := gojiv2.NewMux()
-	for _, route := range routes {
-		switch route.method {
-		case http.MethodGet:
-			mux.HandleFunc(gojiv2pat.Get(route.path), h)
-		case http.MethodPost:
-			mux.HandleFunc(gojiv2pat.Post(route.path), h)
-		case http.MethodPut:
-			mux.HandleFunc(gojiv2pat.Put(route.path), h)
-		case http.MethodPatch:
-			mux.HandleFunc(gojiv2pat.Patch(route.path), h)
-		case http.MethodDelete:
-			mux.HandleFunc(gojiv2pat.Delete(route.path), h)
-		default:
-			panic("Unknown HTTP method: " + route.method)
-		}
-	}
-	return mux
-}
-
-func loadGojiv2Single(method, path string, handler func(http.ResponseWriter, *http.Request)) http.Handler {
-	mux := gojiv2.NewMux()
-	switch method {
-	case http.MethodGet:
-		mux.HandleFunc(gojiv2pat.Get(path), handler)
-	case http.MethodPost:
-		mux.HandleFunc(gojiv2pat.Post(path), handler)
-	case http.MethodPut:
-		mux.HandleFunc(gojiv2pat.Put(path), handler)
-	case http.MethodPatch:
-		mux.HandleFunc(gojiv2pat.Patch(path), handler)
-	case http.MethodDelete:
-		mux.HandleFunc(gojiv2pat.Delete(path), handler)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return mux
-}
-
-// go-json-rest/rest
-func goJsonRestHandler(w rest.ResponseWriter, req *rest.Request) {}
-
-func goJsonRestHandlerWrite(w rest.ResponseWriter, req *rest.Request) {
-	io.WriteString(w.(io.Writer), req.PathParam("name"))
-}
-
-func goJsonRestHandlerTest(w rest.ResponseWriter, req *rest.Request) {
-	io.WriteString(w.(io.Writer), req.RequestURI)
-}
-
-func loadGoJsonRest(routes []route) http.Handler {
-	h := goJsonRestHandler
-	if loadTestHandler {
-		h = goJsonRestHandlerTest
-	}
-
-	api := rest.NewApi()
-	restRoutes := make([]*rest.Route, 0, len(routes))
-	for _, route := range routes {
-		restRoutes = append(restRoutes,
-			&rest.Route{route.method, route.path, h},
-		)
-	}
-	router, err := rest.MakeRouter(restRoutes...)
-	if err != nil {
-		log.Fatal(err)
-	}
-	api.SetApp(router)
-	return api.MakeHandler()
-}
+// Adapter is implemented by every router taking part in the benchmark.
+type Adapter interface {
+	// Name returns the name of the router as used in benchmark names.
+	Name() string
 
-func loadGoJsonRestSingle(method, path string, hfunc rest.HandlerFunc) http.Handler {
-	api := rest.NewApi()
-	router, err := rest.MakeRouter(
-		&rest.Route{method, path, hfunc},
-	)
-	if err != nil {
-		log.Fatal(err)
-	}
-	api.SetApp(router)
-	return api.MakeHandler()
-}
+	// Load returns the router with the given routes registered. If
+	// loadTestHandler is set, the handler writes the request URI, if
+	// loadParamsHandler is set, the route and its parameters with
+	// writeParams.
+	Load(routes []route) http.Handler
 
-// go-restful
-func goRestfulHandler(r *restful.Request, w *restful.Response) {}
+	// LoadSingle returns the router with a single route registered. If write
+	// is set, the handler writes the value of the "name" parameter.
+	LoadSingle(method, path string, write bool) http.Handler
 
-func goRestfulHandlerWrite(r *restful.Request, w *restful.Response) {
-	io.WriteString(w, r.PathParameter("name"))
+	// Features returns the optional features supported by the router.
+	Features() Feature
 }
 
-func goRestfulHandlerTest(r *restful.Request, w *restful.Response) {
-	io.WriteString(w, r.Request.RequestURI)
-}
+// Feature is a set of optional router features.
+type Feature uint
 
-func loadGoRestful(routes []route) http.Handler {
-	h := goRestfulHandler
-	if loadTestHandler {
-		h = goRestfulHandlerTest
-	}
+const (
+	// FeatureParams means the router supports named parameters.
+	FeatureParams Feature = 1 << iota
 
-	re := regexp.MustCompile(":([^/]*)")
+	// FeatureMethodNotAllowed means the router responds with 405 Method Not
+	// Allowed and a valid Allow header to requests for a path with a method
+	// no route of the path has.
+	FeatureMethodNotAllowed
 
-	wsContainer := restful.NewContainer()
-	ws := new(restful.WebService)
+	// FeatureWildcard means the router supports catch-all parameters.
+	FeatureWildcard
 
-	for _, route := range routes {
-		path := re.ReplaceAllString(route.path, "{$1}")
+	// FeatureMixedSegments means the router supports static segments and
+	// parameters at the same position of different routes, like /users/new
+	// and /users/:id, and matches the static segment if the routes are
+	// registered in the order of sortMixedSegments.
+	FeatureMixedSegments
 
-		switch route.method {
-		case http.MethodGet:
-			ws.Route(ws.GET(path).To(h))
-		case http.MethodPost:
-			ws.Route(ws.POST(path).To(h))
-		case http.MethodPut:
-			ws.Route(ws.PUT(path).To(h))
-		case http.MethodPatch:
-			ws.Route(ws.PATCH(path).To(h))
-		case http.MethodDelete:
-			ws.Route(ws.DELETE(path).To(h))
-		default:
-			panic("Unknow HTTP method: " + route.method)
-		}
-	}
-	wsContainer.Add(ws)
-	return wsContainer
-}
+	// FeatureMiddleware means the router registers loadMiddlewares trivial
+	// middlewares with its own middleware API when loading, which count their
+	// calls in middlewareCalls if loadTestHandler is set.
+	FeatureMiddleware
 
-func loadGoRestfulSingle(method, path string, handler restful.RouteFunction) http.Handler {
-	wsContainer := restful.NewContainer()
-	ws := new(restful.WebService)
-	switch method {
-	case http.MethodGet:
-		ws.Route(ws.GET(path).To(handler))
-	case http.MethodPost:
-		ws.Route(ws.POST(path).To(handler))
-	case http.MethodPut:
-		ws.Route(ws.PUT(path).To(handler))
-	case http.MethodPatch:
-		ws.Route(ws.PATCH(path).To(handler))
-	case http.MethodDelete:
-		ws.Route(ws.DELETE(path).To(handler))
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	wsContainer.Add(ws)
-	return wsContainer
-}
+	// FeatureAdd means the adapter implements MutableAdapter.
+	FeatureAdd
 
-// gorilla/mux
-func gorillaHandlerWrite(w http.ResponseWriter, r *http.Request) {
-	params := mux.Vars(r)
-	io.WriteString(w, params["name"])
-}
+	// FeatureConcurrentAdd means routes can be added to the router while it
+	// serves requests, as found by TestRoutersAddConcurrent with the race
+	// detector enabled.
+	FeatureConcurrentAdd
 
-func loadGorillaMux(routes []route) http.Handler {
-	h := httpHandlerFunc
-	if loadTestHandler {
-		h = httpHandlerFuncTest
-	}
+	// FeatureReverse means the adapter implements ReverseAdapter.
+	FeatureReverse
+)
 
-	re := regexp.MustCompile(":([^/]*)")
-	m := mux.NewRouter()
-	for _, route := range routes {
-		m.HandleFunc(
-			re.ReplaceAllString(route.path, "{$1}"),
-			h,
-		).Methods(route.method)
-	}
-	return m
+// Has reports whether all features of x are in f.
+func (f Feature) Has(x Feature) bool {
+	return f&x == x
 }
 
-func loadGorillaMuxSingle(method, path string, handler http.HandlerFunc) http.Handler {
-	m := mux.NewRouter()
-	m.HandleFunc(path, handler).Methods(method)
-	return m
-}
+// UnsupportedAdapter is implemented by the routers lacking features, or only
+// supporting them in part. The tests skip them with the reason, so it has to
+// be known for some features.
+type UnsupportedAdapter interface {
+	Adapter
 
-// gowww/router
-func gowwwRouterHandleWrite(w http.ResponseWriter, r *http.Request) {
-	io.WriteString(w, gowwwrouter.Parameter(r, "name"))
+	// Unsupported returns why the router lacks the feature, or only
+	// supports it in part, or an empty string.
+	Unsupported(f Feature) string
 }
 
-func loadGowwwRouter(routes []route) http.Handler {
-	h := httpHandlerFunc
-	if loadTestHandler {
-		h = httpHandlerFuncTest
+// unsupported returns why the router lacks the feature, see
+// UnsupportedAdapter.
+func unsupported(a Adapter, f Feature) string {
+	if ua, ok := a.(UnsupportedAdapter); ok {
+		return ua.Unsupported(f)
 	}
-
-	router := gowwwrouter.New()
-	for _, route := range routes {
-		router.Handle(route.method, route.path, http.HandlerFunc(h))
-	}
-	return router
-}
-
-func loadGowwwRouterSingle(method, path string, handler http.Handler) http.Handler {
-	router := gowwwrouter.New()
-	router.Handle(method, path, handler)
-	return router
-}
-
-// HttpRouter
-func httpRouterHandle(_ http.ResponseWriter, _ *http.Request, _ httprouter.Params) {}
-
-func httpRouterHandleWrite(w http.ResponseWriter, _ *http.Request, ps httprouter.Params) {
-	io.WriteString(w, ps.ByName("name"))
+	return ""
 }
 
-func httpRouterHandleTest(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	io.WriteString(w, r.RequestURI)
-}
+// adapters holds the registered routers, sorted by name.
+var adapters []Adapter
 
-func loadHttpRouter(routes []route) http.Handler {
-	h := httpRouterHandle
-	if loadTestHandler {
-		h = httpRouterHandleTest
+// register adds a router to the benchmark. It is called from the init
+// function of each router's file.
+func register(a Adapter) {
+	name := strings.ToLower(a.Name())
+	i, found := slices.BinarySearchFunc(adapters, name, func(a Adapter, name string) int {
+		return strings.Compare(strings.ToLower(a.Name()), name)
+	})
+	if found {
+		panic("router registered twice: " + a.Name())
 	}
-
-	router := httprouter.New()
-	for _, route := range routes {
-		router.Handle(route.method, route.path, h)
-	}
-	return router
-}
-
-func loadHttpRouterSingle(method, path string, handle httprouter.Handle) http.Handler {
-	router := httprouter.New()
-	router.Handle(method, path, handle)
-	return router
-}
-
-// httpTreeMux
-func httpTreeMuxHandler(_ http.ResponseWriter, _ *http.Request, _ map[string]string) {}
-
-func httpTreeMuxHandlerWrite(w http.ResponseWriter, _ *http.Request, vars map[string]string) {
-	io.WriteString(w, vars["name"])
-}
-
-func httpTreeMuxHandlerTest(w http.ResponseWriter, r *http.Request, _ map[string]string) {
-	io.WriteString(w, r.RequestURI)
+	adapters = slices.Insert(adapters, i, a)
 }
 
-func loadHttpTreeMux(routes []route) http.Handler {
-	h := httpTreeMuxHandler
-	if loadTestHandler {
-		h = httpTreeMuxHandlerTest
-	}
-
-	router := httptreemux.New()
+// canServe reports whether the router supports all features and methods
+// required by the given routes.
+func canServe(a Adapter, routes []route) bool {
+	f := a.Features()
 	for _, route := range routes {
-		router.Handle(route.method, route.path, h)
-	}
-	return router
-}
-
-func loadHttpTreeMuxSingle(method, path string, handler httptreemux.HandlerFunc) http.Handler {
-	router := httptreemux.New()
-	router.Handle(method, path, handler)
-	return router
-}
-
-// Kocha-urlrouter
-type kochaHandler struct {
-	routerMap map[string]urlrouter.URLRouter
-	params    []urlrouter.Param
-}
-
-func (h *kochaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	meth, params := h.routerMap[r.Method].Lookup(r.URL.Path)
-	h.params = params
-	meth.(http.HandlerFunc).ServeHTTP(w, r)
-}
-
-func (h *kochaHandler) Get(w http.ResponseWriter, r *http.Request)    {}
-func (h *kochaHandler) Post(w http.ResponseWriter, r *http.Request)   {}
-func (h *kochaHandler) Put(w http.ResponseWriter, r *http.Request)    {}
-func (h *kochaHandler) Patch(w http.ResponseWriter, r *http.Request)  {}
-func (h *kochaHandler) Delete(w http.ResponseWriter, r *http.Request) {}
-func (h *kochaHandler) kochaHandlerWrite(w http.ResponseWriter, r *http.Request) {
-	var name string
-	for _, param := range h.params {
-		if param.Name == "name" {
-			name = param.Value
-			break
+		if !supportsMethod(a, route.method) {
+			return false
 		}
-	}
-	io.WriteString(w, name)
-}
-
-func loadKocha(routes []route) http.Handler {
-	/*h := httpRouterHandle
-	if loadTestHandler {
-		h = httpRouterHandleTest
-	}*/
-
-	handler := &kochaHandler{routerMap: map[string]urlrouter.URLRouter{
-		http.MethodGet:    urlrouter.NewURLRouter("doublearray"),
-		http.MethodPost:   urlrouter.NewURLRouter("doublearray"),
-		http.MethodPut:    urlrouter.NewURLRouter("doublearray"),
-		http.MethodPatch:  urlrouter.NewURLRouter("doublearray"),
-		http.MethodDelete: urlrouter.NewURLRouter("doublearray"),
-	}}
-	recordMap := make(map[string][]urlrouter.Record)
-	for _, route := range routes {
-		var f http.HandlerFunc
-		switch route.method {
-		case http.MethodGet:
-			f = handler.Get
-		case http.MethodPost:
-			f = handler.Post
-		case http.MethodPut:
-			f = handler.Put
-		case http.MethodPatch:
-			f = handler.Patch
-		case http.MethodDelete:
-			f = handler.Delete
+		if strings.Contains(route.path, ":") && !f.Has(FeatureParams) {
+			return false
 		}
-		recordMap[route.method] = append(
-			recordMap[route.method],
-			urlrouter.NewRecord(route.path, f),
-		)
-	}
-	for method, records := range recordMap {
-		if err := handler.routerMap[method].Build(records); err != nil {
-			panic(err)
+		if strings.Contains(route.path, "*") && !f.Has(FeatureWildcard) {
+			return false
 		}
 	}
-	return handler
-}
-
-func loadKochaSingle(method, path string, handler *kochaHandler, hfunc http.HandlerFunc) http.Handler {
-	handler.routerMap = map[string]urlrouter.URLRouter{
-		method: urlrouter.NewURLRouter("doublearray"),
-	}
-
-	if err := handler.routerMap[method].Build([]urlrouter.Record{
-		urlrouter.NewRecord(path, hfunc),
-	}); err != nil {
-		panic(err)
-	}
-	return handler
-}
-
-// LARS
-func larsHandler(c lars.Context) {
-}
-
-func larsHandlerWrite(c lars.Context) {
-	io.WriteString(c.Response(), c.Param("name"))
+	return f.Has(FeatureMixedSegments) || !hasMixedSegments(routes)
 }
 
-func larsHandlerTest(c lars.Context) {
-	io.WriteString(c.Response(), c.Request().RequestURI)
-}
-
-func larsNativeHandlerTest(w http.ResponseWriter, r *http.Request) {
-	io.WriteString(w, r.RequestURI)
-}
-
-func loadLARS(routes []route) http.Handler {
-	var h interface{} = larsHandler
-	if loadTestHandler {
-		h = larsHandlerTest
-	}
-
-	l := lars.New()
-
+// hasMixedSegments reports whether routes have a static segment and a
+// parameter at the same position, after the same segments, like /users/new
+// and /users/:id.
+func hasMixedSegments(routes []route) bool {
+	// The kinds of the segments by the path before them, with ":" for the
+	// parameters
+	static := make(map[string]bool)
+	param := make(map[string]bool)
 	for _, r := range routes {
-		switch r.method {
-		case http.MethodGet:
-			l.Get(r.path, h)
-		case http.MethodPost:
-			l.Post(r.path, h)
-		case http.MethodPut:
-			l.Put(r.path, h)
-		case http.MethodPatch:
-			l.Patch(r.path, h)
-		case http.MethodDelete:
-			l.Delete(r.path, h)
-		default:
-			panic("Unknow HTTP method: " + r.method)
+		segments := strings.Split(r.path, "/")
+		for i, segment := range segments {
+			prefix := mixedSegmentsKey(segments[:i])
+			if isParamSegment(segment) {
+				param[prefix] = true
+			} else {
+				static[prefix] = true
+			}
+			if static[prefix] && param[prefix] {
+				return true
+			}
 		}
 	}
-	return l.Serve()
-}
-
-func loadLARSSingle(method, path string, h interface{}) http.Handler {
-	l := lars.New()
-
-	switch method {
-	case http.MethodGet:
-		l.Get(path, h)
-	case http.MethodPost:
-		l.Post(path, h)
-	case http.MethodPut:
-		l.Put(path, h)
-	case http.MethodPatch:
-		l.Patch(path, h)
-	case http.MethodDelete:
-		l.Delete(path, h)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return l.Serve()
+	return false
 }
 
-// Macaron
-func macaronHandler() {}
-
-func macaronHandlerWrite(c *macaron.Context) string {
-	return c.Params("name")
+func isParamSegment(segment string) bool {
+	return strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*")
 }
 
-func macaronHandlerTest(c *macaron.Context) string {
-	return c.Req.RequestURI
-}
-
-func loadMacaron(routes []route) http.Handler {
-	var h = []macaron.Handler{macaronHandler}
-	if loadTestHandler {
-		h[0] = macaronHandlerTest
-	}
-
-	m := macaron.New()
-	for _, route := range routes {
-		m.Handle(route.method, route.path, h)
-	}
-	return m
-}
-
-func loadMacaronSingle(method, path string, handler interface{}) http.Handler {
-	m := macaron.New()
-	m.Handle(method, path, []macaron.Handler{handler})
-	return m
-}
-
-// Martini
-func martiniHandler() {}
-
-func martiniHandlerWrite(params martini.Params) string {
-	return params["name"]
-}
-
-func initMartini() {
-	martini.Env = martini.Prod
-}
-
-func loadMartini(routes []route) http.Handler {
-	var h interface{} = martiniHandler
-	if loadTestHandler {
-		h = httpHandlerFuncTest
-	}
-
-	router := martini.NewRouter()
-	for _, route := range routes {
-		switch route.method {
-		case http.MethodGet:
-			router.Get(route.path, h)
-		case http.MethodPost:
-			router.Post(route.path, h)
-		case http.MethodPut:
-			router.Put(route.path, h)
-		case http.MethodPatch:
-			router.Patch(route.path, h)
-		case http.MethodDelete:
-			router.Delete(route.path, h)
-		default:
-			panic("Unknow HTTP method: " + route.method)
+func mixedSegmentsKey(segments []string) string {
+	key := make([]string, len(segments))
+	for i, segment := range segments {
+		key[i] = segment
+		if isParamSegment(segment) {
+			key[i] = ":"
 		}
 	}
-	martini := martini.New()
-	martini.Action(router.Handle)
-	return martini
-}
-
-func loadMartiniSingle(method, path string, handler interface{}) http.Handler {
-	router := martini.NewRouter()
-	switch method {
-	case http.MethodGet:
-		router.Get(path, handler)
-	case http.MethodPost:
-		router.Post(path, handler)
-	case http.MethodPut:
-		router.Put(path, handler)
-	case http.MethodPatch:
-		router.Patch(path, handler)
-	case http.MethodDelete:
-		router.Delete(path, handler)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-
-	martini := martini.New()
-	martini.Action(router.Handle)
-	return martini
+	return strings.Join(key, "/")
 }
 
-// pat
-func patHandlerWrite(w http.ResponseWriter, r *http.Request) {
-	io.WriteString(w, r.URL.Query().Get(":name"))
+// sortMixedSegments sorts the routes by path, with static segments before
+// parameters, so the routers matching the first route registered for a path
+// match the static segments. The routes of a path stay in order.
+func sortMixedSegments(routes []route) {
+	// 0xff sorts after every byte of a static segment
+	key := strings.NewReplacer(":", "\xff", "*", "\xff")
+	slices.SortStableFunc(routes, func(a, b route) int {
+		return strings.Compare(key.Replace(a.path), key.Replace(b.path))
+	})
 }
 
-func loadPat(routes []route) http.Handler {
-	h := http.HandlerFunc(httpHandlerFunc)
-	if loadTestHandler {
-		h = http.HandlerFunc(httpHandlerFuncTest)
-	}
-
-	m := pat.New()
-	for _, route := range routes {
-		switch route.method {
-		case http.MethodGet:
-			m.Get(route.path, h)
-		case http.MethodPost:
-			m.Post(route.path, h)
-		case http.MethodPut:
-			m.Put(route.path, h)
-		case http.MethodDelete:
-			m.Del(route.path, h)
-		default:
-			panic("Unknow HTTP method: " + route.method)
-		}
-	}
-	return m
-}
-
-func loadPatSingle(method, path string, handler http.Handler) http.Handler {
-	m := pat.New()
-	switch method {
-	case http.MethodGet:
-		m.Get(path, handler)
-	case http.MethodPost:
-		m.Post(path, handler)
-	case http.MethodPut:
-		m.Put(path, handler)
-	case http.MethodDelete:
-		m.Del(path, handler)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return m
-}
-
-// R2router
-func r2routerHandler(w http.ResponseWriter, req *http.Request, _ r2router.Params) {}
-
-func r2routerHandleWrite(w http.ResponseWriter, req *http.Request, params r2router.Params) {
-	io.WriteString(w, params.Get("name"))
-}
-
-func r2routerHandleTest(w http.ResponseWriter, req *http.Request, _ r2router.Params) {
-	io.WriteString(w, req.RequestURI)
-}
-
-func loadR2router(routes []route) http.Handler {
-	h := r2routerHandler
-	if loadTestHandler {
-		h = r2routerHandleTest
-	}
-
-	router := r2router.NewRouter()
-	for _, r := range routes {
-		router.AddHandler(r.method, r.path, h)
-	}
-	return router
-}
-
-func loadR2routerSingle(method, path string, handler r2router.HandlerFunc) http.Handler {
-	router := r2router.NewRouter()
-	router.AddHandler(method, path, handler)
-	return router
-}
-
-// Revel (Router only)
-// In the following code some Revel internals are modeled.
-// The original revel code is copyrighted by Rob Figueiredo.
-// See https://github.com/revel/revel/blob/master/LICENSE
-// type RevelController struct {
-// 	*revel.Controller
-// 	router *revel.Router
-// }
-
-// func (rc *RevelController) Handle() revel.Result {
-// 	return revelResult{}
-// }
-
-// func (rc *RevelController) HandleWrite() revel.Result {
-// 	return rc.RenderText(rc.Params.Get("name"))
-// }
-
-// func (rc *RevelController) HandleTest() revel.Result {
-// 	return rc.RenderText(rc.Request.GetRequestURI())
-// }
-
-// type revelResult struct{}
-
-// func (rr revelResult) Apply(req *revel.Request, resp *revel.Response) {}
-
-// func (rc *RevelController) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-// 	// Dirty hacks, do NOT copy!
-// 	revel.MainRouter = rc.router
-
-// 	upgrade := r.Header.Get("Upgrade")
-// 	if upgrade == "websocket" || upgrade == "Websocket" {
-// 		panic("Not implemented")
-// 	} else {
-// 		var (
-// 			req  = revel.NewRequest(r)
-// 			resp = revel.NewResponse(w)
-// 			c    = revel.NewController(req, resp)
-// 		)
-// 		req.Websocket = nil
-// 		revel.Filters[0](c, revel.Filters[1:])
-// 		if c.Result != nil {
-// 			c.Result.Apply(req, resp)
-// 		} else if c.Response.Status != 0 {
-// 			panic("Not implemented")
-// 		}
-// 		// Close the Writer if we can
-// 		if w, ok := resp.Out.(io.Closer); ok {
-// 			w.Close()
-// 		}
-// 	}
-// }
-
-// func initRevel() {
-// 	// Only use the Revel filters required for this benchmark
-// 	revel.Filters = []revel.Filter{
-// 		revel.RouterFilter,
-// 		revel.ParamsFilter,
-// 		revel.ActionInvoker,
-// 	}
-
-// 	revel.RegisterController((*RevelController)(nil),
-// 		[]*revel.MethodType{
-// 			{
-// 				Name: "Handle",
-// 			},
-// 			{
-// 				Name: "HandleWrite",
-// 			},
-// 			{
-// 				Name: "HandleTest",
-// 			},
-// 		})
-// }
-
-// func loadRevel(routes []route) http.Handler {
-// 	h := "RevelController.Handle"
-// 	if loadTestHandler {
-// 		h = "RevelController.HandleTest"
-// 	}
-
-// 	router := revel.NewRouter("")
-
-// 	// parseRoutes
-// 	var rs []*revel.Route
-// 	for _, r := range routes {
-// 		rs = append(rs, revel.NewRoute(r.method, r.path, h, "", "", 0))
-// 	}
-// 	router.Routes = rs
-
-// 	// updateTree
-// 	router.Tree = pathtree.New()
-// 	for _, r := range router.Routes {
-// 		err := router.Tree.Add(r.TreePath, r)
-// 		// Allow GETs to respond to HEAD requests.
-// 		if err == nil && r.Method == http.MethodGet {
-// 			err = router.Tree.Add("/HEAD"+r.Path, r)
-// 		}
-// 		// Error adding a route to the pathtree.
-// 		if err != nil {
-// 			panic(err)
-// 		}
-// 	}
-
-// 	rc := new(RevelController)
-// 	rc.router = router
-// 	return rc
-// }
-
-// func loadRevelSingle(method, path, action string) http.Handler {
-// 	router := revel.NewRouter("")
-
-// 	route := revel.NewRoute(method, path, action, "", "", 0)
-// 	if err := router.Tree.Add(route.TreePath, route); err != nil {
-// 		panic(err)
-// 	}
-
-// 	rc := new(RevelController)
-// 	rc.router = router
-// 	return rc
-// }
-
-// Rivet
-func rivetHandler() {}
-
-func rivetHandlerWrite(c *rivet.Context) {
-	c.WriteString(c.Get("name"))
-}
-
-func rivetHandlerTest(c *rivet.Context) {
-	c.WriteString(c.Req.RequestURI)
-}
-
-func loadRivet(routes []route) http.Handler {
-	var h interface{} = rivetHandler
-	if loadTestHandler {
-		h = rivetHandlerTest
-	}
-
-	router := rivet.New()
-	for _, route := range routes {
-		router.Handle(route.method, route.path, h)
-	}
-	return router
-}
-
-func loadRivetSingle(method, path string, handler interface{}) http.Handler {
-	router := rivet.New()
-
-	router.Handle(method, path, handler)
-
-	return router
-}
-
-// Tiger Tonic
-func tigerTonicHandlerWrite(w http.ResponseWriter, r *http.Request) {
-	io.WriteString(w, r.URL.Query().Get("name"))
-}
-
-func loadTigerTonic(routes []route) http.Handler {
-	h := httpHandlerFunc
-	if loadTestHandler {
-		h = httpHandlerFuncTest
-	}
-
-	re := regexp.MustCompile(":([^/]*)")
-	mux := tigertonic.NewTrieServeMux()
-	for _, route := range routes {
-		mux.HandleFunc(route.method, re.ReplaceAllString(route.path, "{$1}"), h)
-	}
-	return mux
-}
+func init() {
+	// beego sets it to runtime.NumCPU()
+	// The lookup benchmarks run sequentially, the parallel benchmarks set
+	// their own GOMAXPROCS levels
+	runtime.GOMAXPROCS(1)
 
-func loadTigerTonicSingle(method, path string, handler http.HandlerFunc) http.Handler {
-	mux := tigertonic.NewTrieServeMux()
-	mux.HandleFunc(method, path, handler)
-	return mux
+	// makes logging 'webscale' (ignores them)
+	log.SetOutput(new(mockResponseWriter))
+	nullLogger = log.New(new(mockResponseWriter), "", 0)
 }
 
-// Traffic
-func trafficHandler(w traffic.ResponseWriter, r *traffic.Request) {}
-
-func trafficHandlerWrite(w traffic.ResponseWriter, r *traffic.Request) {
-	io.WriteString(w, r.URL.Query().Get("name"))
-}
+// Common
+func httpHandlerFunc(_ http.ResponseWriter, _ *http.Request) {}
 
-func trafficHandlerTest(w traffic.ResponseWriter, r *traffic.Request) {
+func httpHandlerFuncTest(w http.ResponseWriter, r *http.Request) {
 	io.WriteString(w, r.RequestURI)
 }
-
-func initTraffic() {
-	traffic.SetVar("env", "bench")
-}
-
-func loadTraffic(routes []route) http.Handler {
-	h := trafficHandler
-	if loadTestHandler {
-		h = trafficHandlerTest
-	}
-
-	router := traffic.New()
-	for _, route := range routes {
-		switch route.method {
-		case http.MethodGet:
-			router.Get(route.path, h)
-		case http.MethodPost:
-			router.Post(route.path, h)
-		case http.MethodPut:
-			router.Put(route.path, h)
-		case http.MethodPatch:
-			router.Patch(route.path, h)
-		case http.MethodDelete:
-			router.Delete(route.path, h)
-		default:
-			panic("Unknow HTTP method: " + route.method)
-		}
-	}
-	return router
-}
-
-func loadTrafficSingle(method, path string, handler traffic.HttpHandleFunc) http.Handler {
-	router := traffic.New()
-	switch method {
-	case http.MethodGet:
-		router.Get(path, handler)
-	case http.MethodPost:
-		router.Post(path, handler)
-	case http.MethodPut:
-		router.Put(path, handler)
-	case http.MethodPatch:
-		router.Patch(path, handler)
-	case http.MethodDelete:
-		router.Delete(path, handler)
-	default:
-		panic("Unknow HTTP method: " + method)
-	}
-	return router
-}
-
-// Mailgun Vulcan
-func vulcanHandler(w http.ResponseWriter, r *http.Request) {}
-
-func vulcanHandlerWrite(w http.ResponseWriter, r *http.Request) {
-	io.WriteString(w, r.URL.Query().Get("name"))
-}
-
-func loadVulcan(routes []route) http.Handler {
-	h := httpHandlerFunc
-	if loadTestHandler {
-		h = httpHandlerFuncTest
-	}
-
-	re := regexp.MustCompile(":([^/]*)")
-	mux := vulcan.NewMux()
-	for _, route := range routes {
-		path := re.ReplaceAllString(route.path, "<$1>")
-		expr := fmt.Sprintf(`Method("%s") && Path("%s")`, route.method, path)
-		if err := mux.HandleFunc(expr, h); err != nil {
-			panic(err)
-		}
-	}
-	return mux
-}
-
-func loadVulcanSingle(method, path string, handler http.HandlerFunc) http.Handler {
-	re := regexp.MustCompile(":([^/]*)")
-	mux := vulcan.NewMux()
-	path = re.ReplaceAllString(path, "<$1>")
-	expr := fmt.Sprintf(`Method("%s") && Path("%s")`, method, path)
-	if err := mux.HandleFunc(expr, httpHandlerFunc); err != nil {
-		panic(err)
-	}
-	return mux
-}
-
-// Zeus
-// func zeusHandlerWrite(w http.ResponseWriter, r *http.Request) {
-// 	io.WriteString(w, zeus.Var(r, "name"))
-// }
-
-// func loadZeus(routes []route) http.Handler {
-// 	h := http.HandlerFunc(httpHandlerFunc)
-// 	if loadTestHandler {
-// 		h = http.HandlerFunc(httpHandlerFuncTest)
-// 	}
-
-// 	m := zeus.New()
-// 	for _, route := range routes {
-// 		switch route.method {
-// 		case http.MethodGet:
-// 			m.GET(route.path, h)
-// 		case http.MethodPost:
-// 			m.POST(route.path, h)
-// 		case http.MethodPut:
-// 			m.PUT(route.path, h)
-// 		case http.MethodDelete:
-// 			m.DELETE(route.path, h)
-// 		default:
-// 			panic("Unknow HTTP method: " + route.method)
-// 		}
-// 	}
-// 	return m
-// }
-
-// func loadZeusSingle(method, path string, handler http.HandlerFunc) http.Handler {
-// 	m := zeus.New()
-// 	switch method {
-// 	case http.MethodGet:
-// 		m.GET(path, handler)
-// 	case http.MethodPost:
-// 		m.POST(path, handler)
-// 	case http.MethodPut:
-// 		m.PUT(path, handler)
-// 	case http.MethodDelete:
-// 		m.DELETE(path, handler)
-// 	default:
-// 		panic("Unknow HTTP method: " + method)
-// 	}
-// 	return m
-// }
-
-// Usage notice
-func main() {
-	fmt.Println("Usage: go test -bench=. -timeout=20m")
-	os.Exit(1)
-}
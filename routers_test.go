@@ -3,71 +3,29 @@ package main
 import (
 	"net/http"
 	"net/http/httptest"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 )
 
-var (
-	// load functions of all routers
-	routers = []struct {
-		name string
-		load func(routes []route) http.Handler
-	}{
-		{"Ace", loadAce},
-		{"Bear", loadBear},
-		{"Beego", loadBeego},
-		{"Bone", loadBone},
-		{"Chi", loadChi},
-		{"Superhttp", loadSuperhttp},
-		{"Denco", loadDenco},
-		{"Echo", loadEcho},
-		{"Gin", loadGin},
-		{"GocraftWeb", loadGocraftWeb},
-		{"Goji", loadGoji},
-		{"Gojiv2", loadGojiv2},
-		{"GoJsonRest", loadGoJsonRest},
-		{"GoRestful", loadGoRestful},
-		{"GorillaMux", loadGorillaMux},
-		{"GowwwRouter", loadGowwwRouter},
-		{"HttpRouter", loadHttpRouter},
-		{"HttpTreeMux", loadHttpTreeMux},
-		//{"Kocha", loadKocha},
-		{"LARS", loadLARS},
-		{"Macaron", loadMacaron},
-		{"Martini", loadMartini},
-		{"Pat", loadPat},
-		{"R2router", loadR2router},
-		// {"Revel", loadRevel},
-		{"Rivet", loadRivet},
-		{"TigerTonic", loadTigerTonic},
-		{"Traffic", loadTraffic},
-		{"Vulcan", loadVulcan},
-		// {"Zeus", loadZeus},
-	}
-
-	// all APIs
-	apis = []struct {
-		name   string
-		routes []route
-	}{
-		{"GitHub", githubAPI},
-		{"GPlus", gplusAPI},
-		{"Parse", parseAPI},
-		{"Static", staticRoutes},
-	}
-)
-
 func TestRouters(t *testing.T) {
 	loadTestHandler = true
 
-	for _, router := range routers {
+	for _, router := range adapters {
 		req, _ := http.NewRequest(http.MethodGet, "/", nil)
 		u := req.URL
 		rq := u.RawQuery
 
 		for _, api := range apis {
-			r := router.load(api.routes)
+			if !canServe(router, api.routes) {
+				continue
+			}
+			r := router.Load(api.routes)
 
-			for _, route := range api.routes {
+			for _, route := range requests(api.routes) {
 				w := httptest.NewRecorder()
 				req.Method = route.method
 				req.RequestURI = route.path
@@ -77,7 +35,7 @@ func TestRouters(t *testing.T) {
 				if w.Code != 200 || w.Body.String() != route.path {
 					t.Errorf(
 						"%s in API %s: %d - %s; expected %s %s\n",
-						router.name, api.name, w.Code, w.Body.String(), route.method, route.path,
+						router.Name(), api.name, w.Code, w.Body.String(), route.method, route.path,
 					)
 				}
 			}
@@ -86,3 +44,289 @@ func TestRouters(t *testing.T) {
 
 	loadTestHandler = false
 }
+
+// TestRoutersParams requests each route of the APIs with distinct parameter
+// values and checks that the handler of the route was called with exactly
+// these values, so routers matching the wrong route or extracting the wrong
+// values fail.
+func TestRoutersParams(t *testing.T) {
+	loadParamsHandler = true
+	defer func() { loadParamsHandler = false }()
+
+	for _, router := range adapters {
+		t.Run(router.Name(), func(t *testing.T) {
+			for _, api := range apis {
+				if !canServe(router, api.routes) {
+					continue
+				}
+				// Routers with FeatureParams whose handlers can't get
+				// the values only support it in part
+				if reason := unsupported(router, FeatureParams); reason != "" && slices.ContainsFunc(api.routes, hasParams) {
+					t.Logf("skipping API %s: %s", api.name, reason)
+					continue
+				}
+				h := router.Load(api.routes)
+
+				for _, route := range api.routes {
+					req, want := paramRequest(route)
+					r, _ := http.NewRequest(req.method, req.path, nil)
+					r.RequestURI = req.path
+					w := httptest.NewRecorder()
+					h.ServeHTTP(w, r)
+					if w.Code != 200 || w.Body.String() != want {
+						t.Errorf("API %s: %s %s: %d - %q; expected %q",
+							api.name, req.method, req.path, w.Code, w.Body.String(), want)
+					}
+				}
+			}
+		})
+	}
+}
+
+// hasParams reports whether the route has parameters.
+func hasParams(r route) bool {
+	return strings.ContainsAny(r.path, ":*")
+}
+
+// concurrentAPIs are the APIs routed concurrently by TestRoutersConcurrent.
+var concurrentAPIs = []string{"GitHub", "GPlus", "Parse", "Wildcard"}
+
+// TestRoutersConcurrent routes the requests of the APIs from many goroutines
+// at once and checks that every response belongs to its request. Run it with
+// -race to detect data races as well, e.g. in routers storing per request
+// state in the router. Each router is a subtest, so the race detector fails
+// the router it found the race in. A summary of the unsafe routers, and with
+// -race of the safe ones, is logged at the end.
+func TestRoutersConcurrent(t *testing.T) {
+	const goroutines = 8
+	const rounds = 3
+
+	loadTestHandler = true
+	defer func() { loadTestHandler = false }()
+
+	// The benchmarks run with GOMAXPROCS=1, which would serialize the
+	// goroutines
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(max(4, runtime.NumCPU())))
+
+	var safe, unsafe []string
+	for _, router := range adapters {
+		ok := t.Run(router.Name(), func(t *testing.T) {
+			for _, name := range concurrentAPIs {
+				api := findAPI(name)
+				if !canServe(router, api.routes) {
+					continue
+				}
+				h := router.Load(api.routes)
+				reqs := requests(api.routes)
+
+				var wg sync.WaitGroup
+				var failures atomic.Int32
+				for g := 0; g < goroutines; g++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						n := len(reqs)
+						for i := 0; i < rounds*n; i++ {
+							// Each goroutine starts at another route, so
+							// different routes are looked up at the same time
+							route := reqs[(i+g*n/goroutines)%n]
+							req, _ := http.NewRequest(route.method, route.path, nil)
+							req.RequestURI = route.path
+							w := httptest.NewRecorder()
+							h.ServeHTTP(w, req)
+							if w.Code != 200 || w.Body.String() != route.path {
+								// Only report the first few mismatches
+								if failures.Add(1) <= 5 {
+									t.Errorf("%s in API %s: %d - %s; expected %s %s",
+										router.Name(), api.name, w.Code, w.Body.String(), route.method, route.path)
+								}
+							}
+						}
+					}()
+				}
+				wg.Wait()
+			}
+		})
+		if ok {
+			safe = append(safe, router.Name())
+		} else {
+			unsafe = append(unsafe, router.Name())
+		}
+	}
+
+	// Without the race detector, routers with data races pass as well
+	if raceEnabled {
+		t.Logf("safe: %s", strings.Join(safe, ", "))
+	}
+	if len(unsafe) > 0 {
+		t.Logf("unsafe: %s", strings.Join(unsafe, ", "))
+	}
+}
+
+// TestRoutersNotFound checks that the routers respond with 404 Not Found to
+// requests no route matches. The known deviations are only logged.
+func TestRoutersNotFound(t *testing.T) {
+	loadTestHandler = true
+	defer func() { loadTestHandler = false }()
+
+	for _, router := range adapters {
+		report := t.Errorf
+		if d := deviationOf(router).notFound; d != "" {
+			t.Logf("%s: known deviation: %s", router.Name(), d)
+			report = t.Logf
+		}
+
+		for _, api := range apis {
+			if !canServe(router, api.routes) {
+				continue
+			}
+			h := router.Load(api.routes)
+
+			for _, route := range notFoundRoutes(api.routes) {
+				req, _ := http.NewRequest(route.method, route.path, nil)
+				req.RequestURI = route.path
+				w := httptest.NewRecorder()
+				func() {
+					defer func() {
+						if err := recover(); err != nil {
+							report("%s in API %s: panic for %s %s: %v", router.Name(), api.name, route.method, route.path, err)
+						}
+					}()
+					h.ServeHTTP(w, req)
+					if w.Code != http.StatusNotFound {
+						report("%s in API %s: %d; expected 404 for %s %s",
+							router.Name(), api.name, w.Code, route.method, route.path)
+					}
+				}()
+			}
+		}
+	}
+}
+
+// TestRoutersMethodNotAllowed checks that the routers respond with 404 Not
+// Found or 405 Method Not Allowed to requests for a path with a wrong method,
+// and with 405 and a valid Allow header if they have FeatureMethodNotAllowed.
+// The known deviations are only logged.
+func TestRoutersMethodNotAllowed(t *testing.T) {
+	loadTestHandler = true
+	defer func() { loadTestHandler = false }()
+
+	for _, router := range adapters {
+		report := t.Errorf
+		if d := deviationOf(router).wrongMethod; d != "" {
+			t.Logf("%s: known deviation: %s", router.Name(), d)
+			report = t.Logf
+		}
+		reportAllow := t.Errorf
+		if d := deviationOf(router).allow; d != "" {
+			t.Logf("%s: known deviation: %s", router.Name(), d)
+			reportAllow = t.Logf
+		}
+
+		for _, api := range apis {
+			if !canServe(router, api.routes) {
+				continue
+			}
+			c := checkResponses(router, api, methodNotAllowedRoutes(api.routes))
+			if c.unexpected != "" {
+				report("%s in API %s: %s; expected 404 or 405", router.Name(), api.name, c.unexpected)
+			}
+			if router.Features().Has(FeatureMethodNotAllowed) && c.allow != c.requests {
+				reportAllow("%s in API %s: %d of %d requests with a wrong method got 405 and a valid Allow header",
+					router.Name(), api.name, c.allow, c.requests)
+			}
+		}
+	}
+}
+
+// TestCanServeMethods checks that routers without support for a method of
+// the routes don't serve them, instead of dropping those routes.
+func TestCanServeMethods(t *testing.T) {
+	routes := []route{{http.MethodGet, "/users/:id"}}
+	if !canServe(bearAdapter{}, routes) {
+		t.Errorf("Bear can't serve %v", routes)
+	}
+	routes = append(routes, route{http.MethodPatch, "/users/:id"})
+	if canServe(bearAdapter{}, routes) {
+		t.Errorf("Bear can serve %v without PATCH", routes)
+	}
+}
+
+// TestRoutersWildcard checks that the routers with FeatureWildcard pass the
+// remainder of the path matched by a catch-all parameter to the handler.
+// Routing the Wildcard API is checked by TestRouters.
+func TestRoutersWildcard(t *testing.T) {
+	for _, router := range adapters {
+		t.Run(router.Name(), func(t *testing.T) {
+			if !router.Features().Has(FeatureWildcard) {
+				reason := unsupported(router, FeatureWildcard)
+				if reason == "" {
+					t.Fatal("neither FeatureWildcard nor a reason from Unsupported")
+				}
+				t.Skip(reason)
+			}
+
+			for _, path := range []string{"/files/*name", "/:user/files/*name"} {
+				h := router.LoadSingle(http.MethodGet, path, true)
+				for _, value := range []string{"a", "a/b", wildcardValue} {
+					reqPath := strings.Replace(strings.Replace(path, ":user", "gordon", 1), "*name", value, 1)
+					req, _ := http.NewRequest(http.MethodGet, reqPath, nil)
+					req.RequestURI = reqPath
+					w := httptest.NewRecorder()
+					h.ServeHTTP(w, req)
+
+					// Some routers include the slash before the
+					// remainder
+					if got := strings.TrimPrefix(w.Body.String(), "/"); w.Code != 200 || got != value {
+						t.Errorf("%s for %s: %d - %q; expected %q", path, reqPath, w.Code, w.Body.String(), value)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestRoutersMixedSegments checks that the routers with FeatureMixedSegments
+// match the static segments before the parameters at the same position, with
+// the routes in the order of sortMixedSegments.
+func TestRoutersMixedSegments(t *testing.T) {
+	loadParamsHandler = true
+	defer func() { loadParamsHandler = false }()
+
+	routes := []route{
+		{http.MethodGet, "/users/:id"},
+		{http.MethodPost, "/users/:id"},
+		{http.MethodGet, "/users/:id/repos"},
+		{http.MethodGet, "/users/new"},
+		{http.MethodGet, "/pets/:id/photos"},
+		{http.MethodGet, "/pets/mine/photos"},
+	}
+	sortMixedSegments(routes)
+
+	for _, router := range adapters {
+		if !router.Features().Has(FeatureParams) {
+			continue
+		}
+		t.Run(router.Name(), func(t *testing.T) {
+			if !router.Features().Has(FeatureMixedSegments) {
+				reason := unsupported(router, FeatureMixedSegments)
+				if reason == "" {
+					t.Fatal("neither FeatureMixedSegments nor a reason from Unsupported")
+				}
+				t.Skip(reason)
+			}
+
+			h := router.Load(routes)
+			for _, route := range routes {
+				req, want := paramRequest(route)
+				r, _ := http.NewRequest(req.method, req.path, nil)
+				r.RequestURI = req.path
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, r)
+				if w.Code != 200 || w.Body.String() != want {
+					t.Errorf("%s %s: %d - %q; expected %q", req.method, req.path, w.Code, w.Body.String(), want)
+				}
+			}
+		})
+	}
+}
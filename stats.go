@@ -0,0 +1,153 @@
+package main
+
+import (
+	"math"
+	"slices"
+)
+
+// median returns the median of the samples.
+func median(samples []float64) float64 {
+	s := slices.Clone(samples)
+	slices.Sort(s)
+	n := len(s)
+	if n%2 == 1 {
+		return s[n/2]
+	}
+	return (s[n/2-1] + s[n/2]) / 2
+}
+
+// medianCI returns a confidence interval of the median at the given
+// confidence level, using the order statistics of the samples. The interval
+// is infinite if there are too few samples for that level.
+func medianCI(samples []float64, confidence float64) (lo, hi float64) {
+	s := slices.Clone(samples)
+	slices.Sort(s)
+	n := len(s)
+
+	// [s[k], s[n-1-k]] covers the median with a probability of
+	// 1 - 2*P(X <= k) for X ~ Binomial(n, 1/2), so use the largest k for
+	// which that is still at least the confidence level
+	k := -1
+	for cdf := 0.0; k+1 < n/2; k++ {
+		cdf += binomialPMF(n, k+1)
+		if 1-2*cdf < confidence {
+			break
+		}
+	}
+	if k < 0 {
+		return math.Inf(-1), math.Inf(1)
+	}
+	return s[k], s[n-1-k]
+}
+
+// binomialPMF returns P(X = k) for X ~ Binomial(n, 1/2).
+func binomialPMF(n, k int) float64 {
+	lg := func(x int) float64 {
+		v, _ := math.Lgamma(float64(x + 1))
+		return v
+	}
+	return math.Exp(lg(n) - lg(k) - lg(n-k) - float64(n)*math.Ln2)
+}
+
+// mannWhitneyU returns the two-sided p-value of the Mann-Whitney U-test,
+// which tests whether the samples x and y come from the same distribution
+// without assuming that it is normal. The p-value is exact for small samples
+// without ties, otherwise the normal approximation is used.
+func mannWhitneyU(x, y []float64) float64 {
+	n1, n2 := len(x), len(y)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	// Rank the merged samples, ties get the mean of their ranks
+	type sample struct {
+		v float64
+		x bool
+	}
+	all := make([]sample, 0, n1+n2)
+	for _, v := range x {
+		all = append(all, sample{v, true})
+	}
+	for _, v := range y {
+		all = append(all, sample{v, false})
+	}
+	slices.SortFunc(all, func(a, b sample) int {
+		switch {
+		case a.v < b.v:
+			return -1
+		case a.v > b.v:
+			return 1
+		}
+		return 0
+	})
+
+	var r1, tieSum float64
+	ties := false
+	for i := 0; i < len(all); {
+		j := i + 1
+		for j < len(all) && all[j].v == all[i].v {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for _, s := range all[i:j] {
+			if s.x {
+				r1 += rank
+			}
+		}
+		if t := float64(j - i); t > 1 {
+			ties = true
+			tieSum += t*t*t - t
+		}
+		i = j
+	}
+	u := r1 - float64(n1*(n1+1))/2
+
+	if !ties && n1*n2 <= 400 {
+		return uExactP(n1, n2, int(u))
+	}
+
+	n := float64(n1 + n2)
+	mu := float64(n1*n2) / 2
+	sigma := math.Sqrt(float64(n1*n2) / 12 * (n + 1 - tieSum/(n*(n-1))))
+	if sigma == 0 {
+		return 1
+	}
+	z := math.Max(math.Abs(u-mu)-0.5, 0) / sigma
+	return math.Min(1, math.Erfc(z/math.Sqrt2))
+}
+
+// uExactP returns the two-sided p-value of the statistic u of the
+// Mann-Whitney U-test for sample sizes n1 and n2 without ties.
+func uExactP(n1, n2, u int) float64 {
+	// counts[n][u] is the number of arrangements of n samples of x and the
+	// current number of samples of y with the statistic u
+	maxU := n1 * n2
+	counts := make([][]float64, n1+1)
+	for i := range counts {
+		counts[i] = make([]float64, maxU+1)
+		counts[i][0] = 1
+	}
+	for m := 1; m <= n2; m++ {
+		for i := 1; i <= n1; i++ {
+			// Either the largest sample is from y and adds nothing, or it
+			// is from x and is larger than all m samples of y
+			next := make([]float64, maxU+1)
+			for v := 0; v <= maxU; v++ {
+				next[v] = counts[i][v]
+				if v >= m {
+					next[v] += counts[i-1][v-m]
+				}
+			}
+			counts[i] = next
+		}
+	}
+
+	total, lower := 0.0, 0.0
+	for v, c := range counts[n1] {
+		total += c
+		if v <= min(u, maxU-u) {
+			lower += c
+		}
+	}
+	return math.Min(1, 2*lower/total)
+}
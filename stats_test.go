@@ -0,0 +1,76 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMannWhitneyU(t *testing.T) {
+	tests := []struct {
+		x, y []float64
+		p    float64
+	}{
+		// exact
+		{[]float64{1, 2, 3}, []float64{4, 5, 6}, 0.1},
+		{[]float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, 2.0 / 252},
+		{[]float64{6, 7, 8, 9, 10}, []float64{1, 2, 3, 4, 5}, 2.0 / 252},
+		{[]float64{1, 3, 5}, []float64{2, 4, 6}, 0.7},
+		// with ties
+		{[]float64{1, 1, 1}, []float64{1, 1, 1}, 1},
+	}
+	for _, test := range tests {
+		if p := mannWhitneyU(test.x, test.y); math.Abs(p-test.p) > 1e-9 {
+			t.Errorf("mannWhitneyU(%v, %v) = %v, expected %v", test.x, test.y, p, test.p)
+		}
+	}
+
+	// normal approximation
+	x := []float64{1, 2, 2, 3, 4, 5, 6, 7, 8, 9}
+	y := []float64{11, 12, 12, 13, 14, 15, 16, 17, 18, 19}
+	if p := mannWhitneyU(x, y); p > 0.001 {
+		t.Errorf("mannWhitneyU of separated samples with ties = %v, expected < 0.001", p)
+	}
+}
+
+func TestMedianCI(t *testing.T) {
+	if lo, hi := medianCI([]float64{1, 2, 3, 4, 5}, 0.95); !math.IsInf(lo, -1) || !math.IsInf(hi, 1) {
+		t.Errorf("5 samples: got [%v, %v], expected an infinite interval", lo, hi)
+	}
+	if lo, hi := medianCI([]float64{6, 1, 5, 2, 4, 3}, 0.95); lo != 1 || hi != 6 {
+		t.Errorf("6 samples: got [%v, %v], expected [1, 6]", lo, hi)
+	}
+	// P(X <= 1) = 11/1024 for n = 10, P(X <= 2) = 56/1024
+	if lo, hi := medianCI([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 0.95); lo != 2 || hi != 9 {
+		t.Errorf("10 samples: got [%v, %v], expected [2, 9]", lo, hi)
+	}
+}
+
+func TestCompareResults(t *testing.T) {
+	sample := func(rs *resultSet, router string, nsPerOp ...float64) {
+		for _, v := range nsPerOp {
+			rs.Results = append(rs.Results, result{
+				Benchmark: "GithubAll", API: "GitHub", Router: router, NsPerOp: v,
+			})
+		}
+	}
+	before, after := new(resultSet), new(resultSet)
+	sample(before, "Gin", 100, 101, 102, 103, 104)
+	sample(after, "Gin", 110, 111, 112, 113, 114)
+	sample(before, "HttpRouter", 100, 101, 102, 103, 104)
+	sample(after, "HttpRouter", 100, 102, 104, 101, 103)
+	sample(after, "Chi", 100)
+
+	cs := compareResults(before, after)
+	if len(cs) != 2 {
+		t.Fatalf("got %d comparisons, expected 2", len(cs))
+	}
+	if !cs[0].regression(0.05, 0.05) {
+		t.Errorf("Gin: expected a regression, got delta %v, p %v", cs[0].delta, cs[0].p)
+	}
+	if cs[0].regression(0.05, 0.10) {
+		t.Errorf("Gin: expected no regression beyond 10%%, got delta %v", cs[0].delta)
+	}
+	if cs[1].significant(0.05) {
+		t.Errorf("HttpRouter: expected no significant change, got p %v", cs[1].p)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/wayneashleyberry/superhttp"
+)
+
+func init() {
+	register(superhttpAdapter{})
+}
+
+type superhttpAdapter struct{}
+
+func (superhttpAdapter) Name() string { return "Superhttp" }
+func (superhttpAdapter) Features() Feature {
+	return FeatureParams | FeatureMethodNotAllowed | FeatureWildcard | FeatureMixedSegments |
+		FeatureAdd | FeatureConcurrentAdd
+}
+
+func (superhttpAdapter) Deviation() deviation {
+	return deviation{allow: "like ServeMux, lists the methods of catch-all routes for the path without the trailing slash, which it redirects"}
+}
+
+func (superhttpAdapter) Load(routes []route) http.Handler {
+	return loadSuperhttp(routes)
+}
+
+func (superhttpAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := httpHandlerFunc
+	if write {
+		h = superhttpHandleWrite
+	}
+	path = paramRe.ReplaceAllString(path, "{$1}")
+	path = wildcardRe.ReplaceAllString(path, "{$1...}")
+	return loadSuperhttpSingle(method, path, h)
+}
+
+func (superhttpAdapter) Add(router http.Handler, r route) {
+	h := httpHandlerFunc
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+	superhttpHandle(router.(*superhttp.ServeMux), r.method, superhttpPath(r.path), h)
+}
+
+func superhttpHandleWrite(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, r.PathValue("name"))
+}
+
+func superhttpHandleParams(path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeParams(w, path, func(name string, _ bool) string {
+			return r.PathValue(name)
+		})
+	}
+}
+
+func loadSuperhttp(routes []route) http.Handler {
+	h := httpHandlerFunc
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+
+	mux := superhttp.NewServeMux()
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = superhttpHandleParams(route.path)
+		}
+
+		superhttpHandle(mux, route.method, superhttpPath(route.path), h)
+	}
+	return mux
+}
+
+func superhttpPath(path string) string {
+	path = paramRe.ReplaceAllString(path, "{$1}")
+	path = wildcardRe.ReplaceAllString(path, "{$1...}")
+	if path == "/" {
+		// Only match the root, not every path
+		path = "/{$}"
+	}
+	return path
+}
+
+func loadSuperhttpSingle(method, path string, handler http.HandlerFunc) http.Handler {
+	mux := superhttp.NewServeMux()
+	superhttpHandle(mux, method, path, handler)
+	return mux
+}
+
+func superhttpHandle(mux *superhttp.ServeMux, method, path string, h http.HandlerFunc) {
+	switch method {
+	case http.MethodGet:
+		mux.GET(path, h)
+	case http.MethodPost:
+		mux.POST(path, h)
+	case http.MethodPut:
+		mux.PUT(path, h)
+	case http.MethodPatch:
+		mux.PATCH(path, h)
+	case http.MethodDelete:
+		mux.DELETE(path, h)
+	default:
+		panic("Unknown HTTP method: " + method)
+	}
+}
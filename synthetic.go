@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// routeSpec describes a synthetic route set, see generateRoutes.
+type routeSpec struct {
+	// routes is the total number of routes
+	routes int
+
+	// paramRatio is the share of the routes ending in a parameter, from 0
+	// for only static routes to 1
+	paramRatio float64
+
+	// depth is the number of static segments of each route, from 1 to 26
+	depth int
+
+	// fanOut is the number of children of each inner node of the route
+	// tree, the last segment takes as many values as needed
+	fanOut int
+
+	// prefix is the length of the path prefix shared by all routes, 0 for
+	// none
+	prefix int
+}
+
+// generateRoutes returns the GET routes of the synthetic route set. Route i
+// has the segments a<d0>/b<d1>/... with the digits of i in base fanOut, the
+// last segment counting the remaining multiples. Param routes end in an
+// additional :id segment, they are spread evenly over the route set.
+func generateRoutes(spec routeSpec) []route {
+	if spec.depth < 1 || spec.depth > 26 || spec.fanOut < 1 || spec.paramRatio < 0 || spec.paramRatio > 1 {
+		panic(fmt.Sprintf("invalid route spec: %+v", spec))
+	}
+
+	prefix := ""
+	if spec.prefix > 0 {
+		prefix = "/" + strings.Repeat("p", spec.prefix-1)
+	}
+
+	routes := make([]route, spec.routes)
+	var b strings.Builder
+	for i := range routes {
+		b.Reset()
+		b.WriteString(prefix)
+		n := i
+		for level := 0; level < spec.depth; level++ {
+			digit := n
+			if level < spec.depth-1 {
+				digit = n % spec.fanOut
+				n /= spec.fanOut
+			}
+			b.WriteByte('/')
+			b.WriteByte(byte('a' + level))
+			b.WriteString(strconv.Itoa(digit))
+		}
+		if int(float64(i+1)*spec.paramRatio) > int(float64(i)*spec.paramRatio) {
+			b.WriteString("/:id")
+		}
+		routes[i] = route{http.MethodGet, b.String()}
+	}
+	return routes
+}
+
+// scaleSizes are the route set sizes of the scaling benchmarks.
+var scaleSizes = []int{10, 100, 1000, 10000, 100000}
+
+// scaleSpec returns the spec of the synthetic route set of the scaling
+// benchmarks with the given number of routes.
+func scaleSpec(routes int) routeSpec {
+	return routeSpec{
+		routes:     routes,
+		paramRatio: 0.25,
+		depth:      3,
+		fanOut:     10,
+		prefix:     len("/api/v1"),
+	}
+}
+
+// ScaleAdapter is implemented by the routers which take too long to load large
+// route sets.
+type ScaleAdapter interface {
+	Adapter
+
+	// ScaleLimit returns the largest route set size the router is benchmarked
+	// with.
+	ScaleLimit() int
+}
+
+// scaleSkip returns why the router is left out of the scaling benchmark with
+// the routes, or an empty string if it isn't.
+func scaleSkip(a Adapter, routes []route) string {
+	if !canServe(a, routes) {
+		return "unsupported route set"
+	}
+	if sa, ok := a.(ScaleAdapter); ok && len(routes) > sa.ScaleLimit() {
+		return fmt.Sprintf("takes too long to load more than %d routes", sa.ScaleLimit())
+	}
+	return ""
+}
+
+// scaleSample is the maximum number of routes requested by the scaling
+// benchmarks.
+const scaleSample = 1000
+
+// scaleRequests returns up to scaleSample requests spread evenly over the
+// routes.
+func scaleRequests(routes []route) []route {
+	n := min(len(routes), scaleSample)
+	sample := make([]route, n)
+	for i := range sample {
+		sample[i] = routes[i*len(routes)/n]
+	}
+	return requests(sample)
+}
+
+// benchScale routes one of the requests per operation, so the time per
+// operation is the time of a single lookup.
+func benchScale(b *testing.B, router http.Handler, reqs []route) {
+	w := selectedWriter.new()
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+	u := r.URL
+	rq := u.RawQuery
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		route := reqs[i%len(reqs)]
+		r.Method = route.method
+		r.RequestURI = route.path
+		u.Path = route.path
+		u.RawQuery = rq
+		router.ServeHTTP(w, r)
+		w.finish()
+	}
+}
+
+// scalePoint is the result of a router with a synthetic route set of one
+// size.
+type scalePoint struct {
+	router  string
+	routes  int
+	nsPerOp float64
+	mem     memStats
+}
+
+// runScale runs the scaling benchmarks for every router not skipped by
+// scaleSkip and prints the results in the format of go test.
+func runScale(routers []Adapter, sizes []int) []scalePoint {
+	var points []scalePoint
+	for _, size := range sizes {
+		routes := generateRoutes(scaleSpec(size))
+		reqs := scaleRequests(routes)
+		for _, a := range routers {
+			if scaleSkip(a, routes) != "" {
+				continue
+			}
+			m, err := measureMem(func() http.Handler {
+				return a.Load(routes)
+			})
+			if err != nil {
+				fatalf("%s with %d routes: %v", a.Name(), size, err)
+			}
+			h := a.Load(routes)
+			r := testing.Benchmark(func(b *testing.B) {
+				benchScale(b, h, reqs)
+			})
+			fmt.Printf("BenchmarkScale/%s/routes=%d\t%s\t%s\t%d heap-B\n", a.Name(), size, r.String(), r.MemString(), m.HeapBytes)
+			points = append(points, scalePoint{
+				router:  a.Name(),
+				routes:  size,
+				nsPerOp: float64(r.T.Nanoseconds()) / float64(r.N),
+				mem:     m,
+			})
+		}
+	}
+	return points
+}
+
+// writeScale writes the lookup time and the route table memory of each router
+// over the route set sizes as markdown tables, with a row per router.
+func writeScale(w io.Writer, points []scalePoint) error {
+	bw := bufio.NewWriter(w)
+
+	var routers, sizes []string
+	for _, p := range points {
+		if !slices.Contains(routers, p.router) {
+			routers = append(routers, p.router)
+		}
+		if size := strconv.Itoa(p.routes); !slices.Contains(sizes, size) {
+			sizes = append(sizes, size)
+		}
+	}
+	value := func(f func(p scalePoint) float64) func(router, size string) (float64, bool) {
+		return func(router, size string) (float64, bool) {
+			for _, p := range points {
+				if p.router == router && strconv.Itoa(p.routes) == size {
+					return f(p), true
+				}
+			}
+			return 0, false
+		}
+	}
+
+	fmt.Fprintln(bw, "### Lookup")
+	fmt.Fprintln(bw)
+	fmt.Fprintln(bw, "Time in ns per request, by the number of routes loaded.")
+	fmt.Fprintln(bw)
+	writeTable(bw, routers, sizes, "%s", value(func(p scalePoint) float64 {
+		return p.nsPerOp
+	}))
+	fmt.Fprintln(bw)
+	fmt.Fprintln(bw, "### Memory Consumption")
+	fmt.Fprintln(bw)
+	fmt.Fprintln(bw, "The memory required for the routing structure, by the number of routes loaded.")
+	fmt.Fprintln(bw)
+	writeTable(bw, routers, sizes, "%.0f B", value(func(p scalePoint) float64 {
+		return float64(p.mem.HeapBytes)
+	}))
+	return bw.Flush()
+}
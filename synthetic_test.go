@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGenerateRoutes(t *testing.T) {
+	spec := routeSpec{routes: 1000, paramRatio: 0.25, depth: 4, fanOut: 5, prefix: len("/api/v1")}
+	routes := generateRoutes(spec)
+	if len(routes) != spec.routes {
+		t.Fatalf("got %d routes, expected %d", len(routes), spec.routes)
+	}
+
+	seen := make(map[string]bool)
+	params := 0
+	children := make(map[string]map[string]bool)
+	for _, r := range routes {
+		if seen[r.path] {
+			t.Errorf("duplicate route %s", r.path)
+		}
+		seen[r.path] = true
+
+		if !strings.HasPrefix(r.path, "/pppppp/") {
+			t.Errorf("route %s without the shared prefix", r.path)
+		}
+		path := strings.TrimPrefix(r.path, "/pppppp")
+		if strings.HasSuffix(path, "/:id") {
+			params++
+			path = strings.TrimSuffix(path, "/:id")
+		}
+		segments := strings.Split(path[1:], "/")
+		if len(segments) != spec.depth {
+			t.Errorf("route %s has %d static segments, expected %d", r.path, len(segments), spec.depth)
+		}
+		for i := 1; i < len(segments)-1; i++ {
+			parent := strings.Join(segments[:i], "/")
+			if children[parent] == nil {
+				children[parent] = make(map[string]bool)
+			}
+			children[parent][segments[i]] = true
+		}
+	}
+	if params != 250 {
+		t.Errorf("got %d param routes, expected 250", params)
+	}
+	for parent, c := range children {
+		if len(c) != spec.fanOut {
+			t.Errorf("%s has %d children, expected %d", parent, len(c), spec.fanOut)
+		}
+	}
+}
+
+// TestRoutersSynthetic checks that the routers route a synthetic route set
+// like the scaling benchmarks use.
+func TestRoutersSynthetic(t *testing.T) {
+	loadTestHandler = true
+	defer func() { loadTestHandler = false }()
+
+	routes := generateRoutes(scaleSpec(100))
+	for _, router := range adapters {
+		if !canServe(router, routes) {
+			continue
+		}
+		h := router.Load(routes)
+		for _, route := range requests(routes) {
+			req, _ := http.NewRequest(route.method, route.path, nil)
+			req.RequestURI = route.path
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+			if w.Code != 200 || w.Body.String() != route.path {
+				t.Errorf("%s: %d - %s; expected %s %s", router.Name(), w.Code, w.Body.String(), route.method, route.path)
+			}
+		}
+	}
+}
+
+// Lookup of a single route by the number of routes loaded. The heap-B metric
+// is the memory of the routing structure. Loading the larger route sets takes
+// long, so it only runs with -scale.
+func BenchmarkScale(b *testing.B) {
+	if !opts.scale {
+		b.Skip("run with -scale")
+	}
+	for _, a := range adapters {
+		b.Run(a.Name(), func(b *testing.B) {
+			for _, size := range scaleSizes {
+				routes := generateRoutes(scaleSpec(size))
+				// The function is called for every b.N, the router is
+				// only loaded the first time
+				var h http.Handler
+				var m memStats
+				b.Run(fmt.Sprintf("routes=%d", size), func(b *testing.B) {
+					if reason := scaleSkip(a, routes); reason != "" {
+						b.Skip(reason)
+					}
+					if h == nil {
+						var err error
+						m, err = measureMem(func() http.Handler {
+							return a.Load(routes)
+						})
+						if err != nil {
+							b.Fatal(err)
+						}
+						h = a.Load(routes)
+					}
+					benchScale(b, h, scaleRequests(routes))
+					b.ReportMetric(float64(m.HeapBytes), "heap-B")
+				})
+			}
+		})
+	}
+}
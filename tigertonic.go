@@ -0,0 +1,91 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/rcrowley/go-tigertonic"
+)
+
+func init() {
+	register(tigerTonicAdapter{})
+}
+
+type tigerTonicAdapter struct{}
+
+func (tigerTonicAdapter) Name() string { return "TigerTonic" }
+func (tigerTonicAdapter) Features() Feature {
+	return FeatureParams | FeatureMethodNotAllowed | FeatureMixedSegments | FeatureAdd
+}
+
+func (tigerTonicAdapter) Unsupported(f Feature) string {
+	switch f {
+	case FeatureWildcard:
+		return "parameters can't match more than one segment"
+	}
+	return ""
+}
+
+func (tigerTonicAdapter) Deviation() deviation {
+	return deviation{notFound: "responds with 405 Method Not Allowed to some prefixes of routes"}
+}
+
+func (tigerTonicAdapter) Load(routes []route) http.Handler {
+	return loadTigerTonic(routes)
+}
+
+func (tigerTonicAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := httpHandlerFunc
+	if write {
+		h = tigerTonicHandlerWrite
+	}
+	path = paramRe.ReplaceAllString(path, "{$1}")
+	return loadTigerTonicSingle(method, path, h)
+}
+
+func (tigerTonicAdapter) Add(router http.Handler, r route) {
+	h := httpHandlerFunc
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+	router.(*tigertonic.TrieServeMux).HandleFunc(r.method, paramRe.ReplaceAllString(r.path, "{$1}"), h)
+}
+
+func tigerTonicHandlerWrite(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, r.URL.Query().Get("name"))
+}
+
+func tigerTonicHandlerParams(path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		writeParams(w, path, func(name string, _ bool) string {
+			return query.Get(name)
+		})
+	}
+}
+
+func loadTigerTonic(routes []route) http.Handler {
+	h := httpHandlerFunc
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+
+	mux := tigertonic.NewTrieServeMux()
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = tigerTonicHandlerParams(route.path)
+		}
+		mux.HandleFunc(route.method, paramRe.ReplaceAllString(route.path, "{$1}"), h)
+	}
+	return mux
+}
+
+func loadTigerTonicSingle(method, path string, handler http.HandlerFunc) http.Handler {
+	mux := tigertonic.NewTrieServeMux()
+	mux.HandleFunc(method, path, handler)
+	return mux
+}
@@ -0,0 +1,119 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/pilu/traffic"
+)
+
+func init() {
+	initTraffic()
+	register(trafficAdapter{})
+}
+
+type trafficAdapter struct{}
+
+func (trafficAdapter) Name() string { return "Traffic" }
+func (trafficAdapter) Features() Feature {
+	return FeatureParams | FeatureWildcard | FeatureMixedSegments | FeatureAdd
+}
+
+func (trafficAdapter) Load(routes []route) http.Handler {
+	return loadTraffic(routes)
+}
+
+func (trafficAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := trafficHandler
+	if write {
+		h = trafficHandlerWrite
+	}
+	path = wildcardRe.ReplaceAllString(path, ":$1*")
+	return loadTrafficSingle(method, path, h)
+}
+
+func (trafficAdapter) Add(router http.Handler, r route) {
+	h := trafficHandler
+	if loadTestHandler {
+		h = trafficHandlerTest
+	}
+	router.(*traffic.Router).Add(traffic.HttpMethod(r.method), wildcardRe.ReplaceAllString(r.path, ":$1*"), h)
+}
+
+func trafficHandler(w traffic.ResponseWriter, r *traffic.Request) {}
+
+func trafficHandlerWrite(w traffic.ResponseWriter, r *traffic.Request) {
+	io.WriteString(w, r.URL.Query().Get("name"))
+}
+
+func trafficHandlerTest(w traffic.ResponseWriter, r *traffic.Request) {
+	io.WriteString(w, r.RequestURI)
+}
+
+func initTraffic() {
+	traffic.SetVar("env", "bench")
+}
+
+func trafficHandlerParams(path string) traffic.HttpHandleFunc {
+	return func(w traffic.ResponseWriter, r *traffic.Request) {
+		query := r.URL.Query()
+		writeParams(w, path, func(name string, _ bool) string {
+			return query.Get(name)
+		})
+	}
+}
+
+func loadTraffic(routes []route) http.Handler {
+	h := trafficHandler
+	if loadTestHandler {
+		h = trafficHandlerTest
+	}
+
+	router := traffic.New()
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = trafficHandlerParams(route.path)
+		}
+
+		path := wildcardRe.ReplaceAllString(route.path, ":$1*")
+
+		switch route.method {
+		case http.MethodGet:
+			router.Get(path, h)
+		case http.MethodPost:
+			router.Post(path, h)
+		case http.MethodPut:
+			router.Put(path, h)
+		case http.MethodPatch:
+			router.Patch(path, h)
+		case http.MethodDelete:
+			router.Delete(path, h)
+		default:
+			panic("Unknow HTTP method: " + route.method)
+		}
+	}
+	return router
+}
+
+func loadTrafficSingle(method, path string, handler traffic.HttpHandleFunc) http.Handler {
+	router := traffic.New()
+	switch method {
+	case http.MethodGet:
+		router.Get(path, handler)
+	case http.MethodPost:
+		router.Post(path, handler)
+	case http.MethodPut:
+		router.Put(path, handler)
+	case http.MethodPatch:
+		router.Patch(path, handler)
+	case http.MethodDelete:
+		router.Delete(path, handler)
+	default:
+		panic("Unknow HTTP method: " + method)
+	}
+	return router
+}
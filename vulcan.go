@@ -0,0 +1,116 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	vulcan "github.com/vulcand/route"
+)
+
+func init() {
+	register(vulcanAdapter{})
+}
+
+type vulcanAdapter struct{}
+
+func (vulcanAdapter) Name() string { return "Vulcan" }
+func (vulcanAdapter) Features() Feature {
+	return FeatureParams | FeatureAdd | FeatureConcurrentAdd
+}
+
+func (vulcanAdapter) Unsupported(f Feature) string {
+	switch f {
+	case FeatureParams:
+		return "the parameter values are not available to the handler"
+	case FeatureWildcard:
+		return "the remainder matched by <path:name> is not available to the handler"
+	case FeatureMixedSegments:
+		return "matches the parameter instead of the static segment"
+	}
+	return ""
+}
+
+// Loading 3000 routes takes minutes
+func (vulcanAdapter) ScaleLimit() int { return 1000 }
+
+func (vulcanAdapter) Load(routes []route) http.Handler {
+	return loadVulcan(routes)
+}
+
+func (vulcanAdapter) LoadSingle(method, path string, write bool) http.Handler {
+	h := vulcanHandler
+	if write {
+		h = vulcanHandlerWrite
+	}
+	return loadVulcanSingle(method, path, h)
+}
+
+func (vulcanAdapter) Add(router http.Handler, r route) {
+	h := httpHandlerFunc
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+	if err := router.(*vulcan.Mux).HandleFunc(vulcanExpr(r.method, r.path), h); err != nil {
+		panic(err)
+	}
+}
+
+func (vulcanAdapter) Remove(router http.Handler, r route) {
+	if err := router.(*vulcan.Mux).Remove(vulcanExpr(r.method, r.path)); err != nil {
+		panic(err)
+	}
+}
+
+func vulcanHandler(w http.ResponseWriter, r *http.Request) {}
+
+func vulcanHandlerWrite(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, r.URL.Query().Get("name"))
+}
+
+// vulcanHandlerParams only writes the route, the parameter values are not
+// available to the handler.
+func vulcanHandlerParams(path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeParams(w, path, func(string, bool) string {
+			return ""
+		})
+	}
+}
+
+func loadVulcan(routes []route) http.Handler {
+	h := httpHandlerFunc
+	if loadTestHandler {
+		h = httpHandlerFuncTest
+	}
+
+	mux := vulcan.NewMux()
+	for _, route := range routes {
+		if loadParamsHandler {
+			h = vulcanHandlerParams(route.path)
+		}
+
+		if err := mux.HandleFunc(vulcanExpr(route.method, route.path), h); err != nil {
+			panic(err)
+		}
+	}
+	return mux
+}
+
+func loadVulcanSingle(method, path string, handler http.HandlerFunc) http.Handler {
+	mux := vulcan.NewMux()
+	if err := mux.HandleFunc(vulcanExpr(method, path), handler); err != nil {
+		panic(err)
+	}
+	return mux
+}
+
+// vulcanExpr returns the routing expression matching the method and path.
+func vulcanExpr(method, path string) string {
+	path = paramRe.ReplaceAllString(path, "<$1>")
+	return fmt.Sprintf(`Method("%s") && Path("%s")`, method, path)
+}
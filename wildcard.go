@@ -0,0 +1,38 @@
+package main
+
+import (
+	"net/http"
+	"slices"
+)
+
+// wildcardAPI is the GitHub API including the routes with catch-all
+// parameters, which the GitHub API leaves out since not all routers support
+// them.
+var wildcardAPI = append(slices.Clone(githubAPI),
+	route{http.MethodGet, "/repos/:owner/:repo/git/refs/*ref"},
+	route{http.MethodPatch, "/repos/:owner/:repo/git/refs/*ref"},
+	route{http.MethodDelete, "/repos/:owner/:repo/git/refs/*ref"},
+	route{http.MethodGet, "/repos/:owner/:repo/contents/*path"},
+	route{http.MethodPut, "/repos/:owner/:repo/contents/*path"},
+	route{http.MethodDelete, "/repos/:owner/:repo/contents/*path"},
+)
+
+// wildcardValue is the remainder of the path requested for catch-all
+// parameters.
+const wildcardValue = "heads/feature/docs/README.md"
+
+// requestPath returns the path requested for the route path, with the
+// catch-all parameter replaced by wildcardValue. Named parameters are
+// requested with their name as value.
+func requestPath(path string) string {
+	return wildcardRe.ReplaceAllLiteralString(path, wildcardValue)
+}
+
+// requests returns the requests for the routes, see requestPath.
+func requests(routes []route) []route {
+	reqs := make([]route, len(routes))
+	for i, r := range routes {
+		reqs[i] = route{r.method, requestPath(r.path)}
+	}
+	return reqs
+}
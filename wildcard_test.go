@@ -0,0 +1,25 @@
+package main
+
+import (
+	"testing"
+)
+
+// Catch-all parameter
+func BenchmarkWildcardParam(b *testing.B) {
+	runBenchmark(b, "WildcardParam")
+}
+
+// All routes
+func BenchmarkWildcardAll(b *testing.B) {
+	runBenchmark(b, "WildcardAll")
+}
+
+// Paths no route matches
+func BenchmarkWildcardNotFound(b *testing.B) {
+	runBenchmark(b, "WildcardNotFound")
+}
+
+// Building the router from all routes
+func BenchmarkWildcardLoad(b *testing.B) {
+	runBenchmark(b, "WildcardLoad")
+}
@@ -0,0 +1,410 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// benchWriter is the ResponseWriter the lookup benchmarks pass to the
+// routers.
+type benchWriter interface {
+	http.ResponseWriter
+
+	// finish completes the response after the router served the request,
+	// like the server does, and prepares the writer for the next one
+	finish()
+}
+
+// writerMode is a kind of benchWriter.
+type writerMode struct {
+	name string
+
+	// suffix is appended to the names of the benchmarks added by
+	// addWriterBenchmarks
+	suffix string
+
+	new func() benchWriter
+}
+
+// writerModes are the benchWriters to choose from, the first is the default.
+var writerModes = []writerMode{
+	{"discard", "Discard", func() benchWriter { return new(mockResponseWriter) }},
+	{"recorder", "Recorder", func() benchWriter { return newRecorderWriter() }},
+	{"buffered", "Buffered", func() benchWriter { return newBufferedWriter() }},
+	{"nethttp", "NetHTTP", func() benchWriter { return newNetHTTPWriter() }},
+}
+
+// selectedWriter is the mode of the writers of the lookup benchmarks.
+var selectedWriter = writerModes[0]
+
+// findWriterMode returns the writer mode with the given name.
+func findWriterMode(name string) (writerMode, error) {
+	i := slices.IndexFunc(writerModes, func(m writerMode) bool { return m.name == name })
+	if i < 0 {
+		names := make([]string, len(writerModes))
+		for i, m := range writerModes {
+			names[i] = m.name
+		}
+		return writerMode{}, fmt.Errorf("unknown writer mode %q, expected one of %s", name, strings.Join(names, ", "))
+	}
+	return writerModes[i], nil
+}
+
+// finish does nothing, the mockResponseWriter discards everything.
+func (m *mockResponseWriter) finish() {}
+
+// recorderWriter retains the headers, the status code and the number of bytes
+// written until the response is finished, but discards the body.
+type recorderWriter struct {
+	header http.Header
+	status int
+	size   int
+}
+
+func newRecorderWriter() *recorderWriter {
+	return &recorderWriter{header: make(http.Header)}
+}
+
+func (w *recorderWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *recorderWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *recorderWriter) Write(p []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	w.size += len(p)
+	return len(p), nil
+}
+
+func (w *recorderWriter) WriteString(s string) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	w.size += len(s)
+	return len(s), nil
+}
+
+func (w *recorderWriter) finish() {
+	clear(w.header)
+	w.status = 0
+	w.size = 0
+}
+
+// bufferWriterSize is the size of the buffers of the buffered and the
+// net/http-like writers, the same as net/http's.
+const bufferWriterSize = 4 << 10
+
+// bufferedWriter writes the response, the status line and the headers before
+// the body, to a buffer over a connection discarding everything. It
+// implements http.Flusher and http.Hijacker.
+type bufferedWriter struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	hijacked    bool
+	buf         *bufio.Writer
+	scratch     []byte
+}
+
+func newBufferedWriter() *bufferedWriter {
+	return &bufferedWriter{
+		header: make(http.Header),
+		buf:    bufio.NewWriterSize(discardConn{}, bufferWriterSize),
+	}
+}
+
+func (w *bufferedWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+// writeHeader writes the status line and the headers, unless they are
+// written already.
+func (w *bufferedWriter) writeHeader() {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.WriteHeader(http.StatusOK)
+	w.scratch = appendStatusLine(w.scratch[:0], w.status)
+	w.buf.Write(w.scratch)
+	w.header.Write(w.buf)
+	w.buf.WriteString("\r\n")
+}
+
+func (w *bufferedWriter) Write(p []byte) (int, error) {
+	w.writeHeader()
+	return w.buf.Write(p)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.writeHeader()
+	return w.buf.WriteString(s)
+}
+
+func (w *bufferedWriter) Flush() {
+	w.writeHeader()
+	w.buf.Flush()
+}
+
+func (w *bufferedWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if w.hijacked {
+		return nil, nil, http.ErrHijacked
+	}
+	w.hijacked = true
+	return discardConn{}, bufio.NewReadWriter(bufio.NewReader(discardConn{}), w.buf), nil
+}
+
+func (w *bufferedWriter) finish() {
+	if !w.hijacked {
+		w.writeHeader()
+		w.buf.Flush()
+	}
+	clear(w.header)
+	w.status = 0
+	w.wroteHeader = false
+	w.hijacked = false
+}
+
+// bufferBeforeChunking is the size of the body the net/http-like writer
+// buffers to send it with a Content-Length, the same as net/http's. Larger
+// bodies are sent chunked.
+const bufferBeforeChunking = 2 << 10
+
+// netHTTPWriter writes the response the way the HTTP/1.1 server of net/http
+// does: the headers are a new map for every request and are copied when the
+// status code is written, the body is buffered to send it with a
+// Content-Length or sent chunked if it is larger, the Content-Type is sniffed
+// if missing and a Date header is added. It implements http.Flusher and
+// http.Hijacker.
+type netHTTPWriter struct {
+	// handlerHeader is returned by Header, header is its copy written
+	header        http.Header
+	handlerHeader http.Header
+
+	status   int
+	chunking bool
+	hijacked bool
+	body     []byte
+	buf      *bufio.Writer
+	scratch  []byte
+}
+
+func newNetHTTPWriter() *netHTTPWriter {
+	return &netHTTPWriter{
+		handlerHeader: make(http.Header),
+		body:          make([]byte, 0, bufferBeforeChunking),
+		buf:           bufio.NewWriterSize(discardConn{}, bufferWriterSize),
+	}
+}
+
+func (w *netHTTPWriter) Header() http.Header {
+	return w.handlerHeader
+}
+
+func (w *netHTTPWriter) WriteHeader(code int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = code
+	w.header = w.handlerHeader.Clone()
+}
+
+// writeHeader writes the status line and the headers, with the
+// Content-Length of the buffered body or chunked.
+func (w *netHTTPWriter) writeHeader() {
+	w.scratch = appendStatusLine(w.scratch[:0], w.status)
+	w.buf.Write(w.scratch)
+	w.header.Write(w.buf)
+	if _, ok := w.header["Content-Type"]; !ok && len(w.body) > 0 {
+		w.buf.WriteString("Content-Type: ")
+		w.buf.WriteString(http.DetectContentType(w.body))
+		w.buf.WriteString("\r\n")
+	}
+	w.buf.WriteString("Date: ")
+	w.buf.Write(time.Now().UTC().AppendFormat(w.scratch[:0], http.TimeFormat))
+	w.buf.WriteString("\r\n")
+	if w.chunking {
+		w.buf.WriteString("Transfer-Encoding: chunked\r\n\r\n")
+		return
+	}
+	w.buf.WriteString("Content-Length: ")
+	w.buf.Write(strconv.AppendInt(w.scratch[:0], int64(len(w.body)), 10))
+	w.buf.WriteString("\r\n\r\n")
+}
+
+// buffer reports whether n more bytes of the body fit into the buffer. If
+// they don't, the response is switched to chunked and the buffered body sent.
+func (w *netHTTPWriter) buffer(n int) bool {
+	w.WriteHeader(http.StatusOK)
+	if w.chunking {
+		return false
+	}
+	if len(w.body)+n <= cap(w.body) {
+		return true
+	}
+	w.startChunking()
+	return false
+}
+
+// startChunking writes the headers and the buffered body as the first chunk.
+func (w *netHTTPWriter) startChunking() {
+	w.chunking = true
+	w.writeHeader()
+	if len(w.body) > 0 {
+		w.writeChunkHeader(len(w.body))
+		w.buf.Write(w.body)
+		w.buf.WriteString("\r\n")
+		w.body = w.body[:0]
+	}
+}
+
+func (w *netHTTPWriter) writeChunkHeader(n int) {
+	w.buf.Write(strconv.AppendInt(w.scratch[:0], int64(n), 16))
+	w.buf.WriteString("\r\n")
+}
+
+func (w *netHTTPWriter) Write(p []byte) (int, error) {
+	if w.buffer(len(p)) {
+		w.body = append(w.body, p...)
+		return len(p), nil
+	}
+	if len(p) > 0 {
+		w.writeChunkHeader(len(p))
+		w.buf.Write(p)
+		w.buf.WriteString("\r\n")
+	}
+	return len(p), nil
+}
+
+func (w *netHTTPWriter) WriteString(s string) (int, error) {
+	if w.buffer(len(s)) {
+		w.body = append(w.body, s...)
+		return len(s), nil
+	}
+	if len(s) > 0 {
+		w.writeChunkHeader(len(s))
+		w.buf.WriteString(s)
+		w.buf.WriteString("\r\n")
+	}
+	return len(s), nil
+}
+
+func (w *netHTTPWriter) Flush() {
+	w.WriteHeader(http.StatusOK)
+	if !w.chunking {
+		w.startChunking()
+	}
+	w.buf.Flush()
+}
+
+func (w *netHTTPWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if w.hijacked {
+		return nil, nil, http.ErrHijacked
+	}
+	w.hijacked = true
+	return discardConn{}, bufio.NewReadWriter(bufio.NewReader(discardConn{}), w.buf), nil
+}
+
+func (w *netHTTPWriter) finish() {
+	if !w.hijacked {
+		w.WriteHeader(http.StatusOK)
+		if w.chunking {
+			w.buf.WriteString("0\r\n\r\n")
+		} else {
+			w.writeHeader()
+			w.buf.Write(w.body)
+		}
+		w.buf.Flush()
+	}
+	w.handlerHeader = make(http.Header)
+	w.header = nil
+	w.status = 0
+	w.chunking = false
+	w.hijacked = false
+	w.body = w.body[:0]
+}
+
+// appendStatusLine appends the HTTP/1.1 status line of the status code.
+func appendStatusLine(b []byte, code int) []byte {
+	b = append(b, "HTTP/1.1 "...)
+	b = strconv.AppendInt(b, int64(code), 10)
+	b = append(b, ' ')
+	b = append(b, http.StatusText(code)...)
+	return append(b, "\r\n"...)
+}
+
+// discardConn is the connection of the buffered writers, reading nothing and
+// discarding everything written.
+type discardConn struct{}
+
+// loopbackAddr is the address of both ends of a discardConn.
+var loopbackAddr = &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}
+
+func (discardConn) Read([]byte) (int, error)         { return 0, io.EOF }
+func (discardConn) Write(p []byte) (int, error)      { return len(p), nil }
+func (discardConn) Close() error                     { return nil }
+func (discardConn) LocalAddr() net.Addr              { return loopbackAddr }
+func (discardConn) RemoteAddr() net.Addr             { return loopbackAddr }
+func (discardConn) SetDeadline(time.Time) error      { return nil }
+func (discardConn) SetReadDeadline(time.Time) error  { return nil }
+func (discardConn) SetWriteDeadline(time.Time) error { return nil }
+
+// writerBenchmarks are the names of the benchmarks added by
+// addWriterBenchmarks.
+var writerBenchmarks []string
+
+// writerBenchmarkBases are the benchmarks addWriterBenchmarks adds a variant
+// of for each writer mode, besides the All and MethodNotAllowed benchmarks of
+// the APIs.
+var writerBenchmarkBases = []string{"ParamWrite"}
+
+// addWriterBenchmarks adds a variant of ParamWrite and of the All and
+// MethodNotAllowed benchmarks of each API for each of the writerModes, named
+// with the suffix of the mode, e.g. GithubAllNetHTTP.
+func addWriterBenchmarks() {
+	bases := slices.Clone(writerBenchmarkBases)
+	for _, api := range apis {
+		bases = append(bases, benchPrefix(api.name)+"All", benchPrefix(api.name)+"MethodNotAllowed")
+	}
+	for _, base := range bases {
+		i := slices.IndexFunc(benchmarks, func(bm benchmark) bool { return bm.name == base })
+		if i < 0 {
+			continue
+		}
+		for _, mode := range writerModes {
+			bm := writerBenchmark(benchmarks[i], mode)
+			benchmarks = append(benchmarks, bm)
+			writerBenchmarks = append(writerBenchmarks, bm.name)
+		}
+	}
+}
+
+// writerBenchmark returns the variant of the benchmark running with the
+// writers of the mode instead of the selectedWriter.
+func writerBenchmark(bm benchmark, mode writerMode) benchmark {
+	run := bm.run
+	bm.name += mode.suffix
+	bm.run = func(b *testing.B, a Adapter) {
+		defer func(w writerMode) { selectedWriter = w }(selectedWriter)
+		selectedWriter = mode
+		run(b, a)
+	}
+	return bm
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// readResponse returns the response written to out, which has to be complete.
+func readResponse(t *testing.T, out *bytes.Buffer) (*http.Response, string) {
+	t.Helper()
+	resp, err := http.ReadResponse(bufio.NewReader(out), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if out.Len() > 0 {
+		t.Errorf("%d bytes after the response: %q", out.Len(), out.String())
+	}
+	return resp, string(body)
+}
+
+func TestNetHTTPWriter(t *testing.T) {
+	large := strings.Repeat("x", bufferBeforeChunking+1)
+	tests := []struct {
+		name        string
+		handler     http.HandlerFunc
+		status      int
+		contentType string
+		body        string
+		chunked     bool
+	}{
+		{"empty", func(w http.ResponseWriter, r *http.Request) {}, 200, "", "", false},
+		{"sniffed", func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, "<html>")
+		}, 200, "text/html; charset=utf-8", "<html>", false},
+		{"headers", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			// Headers set after WriteHeader aren't sent
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("{}"))
+		}, 201, "application/json", "{}", false},
+		{"chunked", func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, large[:10])
+			io.WriteString(w, large[10:])
+		}, 200, "text/plain; charset=utf-8", large, true},
+		{"flushed", func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, "a")
+			w.(http.Flusher).Flush()
+			io.WriteString(w, "b")
+		}, 200, "text/plain; charset=utf-8", "ab", true},
+	}
+
+	w := newNetHTTPWriter()
+	var out bytes.Buffer
+	w.buf = bufio.NewWriter(&out)
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+	for _, tt := range tests {
+		// The same writer for all of them, like in the benchmarks
+		tt.handler(w, r)
+		w.finish()
+
+		resp, body := readResponse(t, &out)
+		if resp.StatusCode != tt.status || body != tt.body {
+			t.Errorf("%s: %d - %.20q; expected %d - %.20q", tt.name, resp.StatusCode, body, tt.status, tt.body)
+		}
+		if got := resp.Header.Get("Content-Type"); got != tt.contentType {
+			t.Errorf("%s: Content-Type %q, expected %q", tt.name, got, tt.contentType)
+		}
+		if resp.Header.Get("Date") == "" {
+			t.Errorf("%s: no Date header", tt.name)
+		}
+		if chunked := len(resp.TransferEncoding) > 0; chunked != tt.chunked {
+			t.Errorf("%s: chunked %t, expected %t", tt.name, chunked, tt.chunked)
+		}
+		if !tt.chunked && resp.ContentLength != int64(len(tt.body)) {
+			t.Errorf("%s: Content-Length %d, expected %d", tt.name, resp.ContentLength, len(tt.body))
+		}
+	}
+}
+
+func TestWriterModes(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Allow", "GET")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		io.WriteString(w, "no")
+	}
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+
+	for _, mode := range writerModes {
+		w := mode.new()
+		// Only the mockResponseWriter discards the headers
+		w.Header().Set("Allow", "GET")
+		if retained := w.Header().Get("Allow") == "GET"; retained != (mode.name != "discard") {
+			t.Errorf("%s: retained headers %t", mode.name, retained)
+		}
+		w.finish()
+		for range 2 {
+			handler(w, r)
+			w.finish()
+		}
+		_, flusher := w.(http.Flusher)
+		_, hijacker := w.(http.Hijacker)
+		if buffered := mode.name == "buffered" || mode.name == "nethttp"; flusher != buffered || hijacker != buffered {
+			t.Errorf("%s: Flusher %t and Hijacker %t, expected %t", mode.name, flusher, hijacker, buffered)
+		}
+	}
+
+	rec := newRecorderWriter()
+	handler(rec, r)
+	if rec.status != http.StatusMethodNotAllowed || rec.size != 2 || rec.header.Get("Allow") != "GET" {
+		t.Errorf("recorded %d, %d bytes and %v", rec.status, rec.size, rec.header)
+	}
+	rec.finish()
+	if rec.status != 0 || rec.size != 0 || len(rec.header) != 0 {
+		t.Errorf("recorded %d, %d bytes and %v after finish", rec.status, rec.size, rec.header)
+	}
+
+	buf := newBufferedWriter()
+	var out bytes.Buffer
+	buf.buf = bufio.NewWriter(&out)
+	handler(buf, r)
+	buf.finish()
+	resp, body := readResponse(t, &out)
+	if resp.StatusCode != http.StatusMethodNotAllowed || body != "no" || resp.Header.Get("Allow") != "GET" {
+		t.Errorf("buffered: %d - %q with %v", resp.StatusCode, body, resp.Header)
+	}
+
+	if _, err := findWriterMode("chunked"); err == nil {
+		t.Error("found unknown writer mode")
+	}
+}
+
+// TestRoutersWriters checks that the routers route the requests of the GitHub
+// API with each of the writerModes.
+func TestRoutersWriters(t *testing.T) {
+	loadTestHandler = true
+	defer func() { loadTestHandler = false }()
+
+	for _, router := range adapters {
+		if !canServe(router, githubAPI) {
+			continue
+		}
+		h := router.Load(githubAPI)
+		t.Run(router.Name(), func(t *testing.T) {
+			for _, mode := range writerModes {
+				w := mode.new()
+				var out bytes.Buffer
+				switch w := w.(type) {
+				case *bufferedWriter:
+					w.buf = bufio.NewWriter(&out)
+				case *netHTTPWriter:
+					w.buf = bufio.NewWriter(&out)
+				}
+				for _, route := range requests(githubAPI) {
+					r, _ := http.NewRequest(route.method, route.path, nil)
+					r.RequestURI = route.path
+					h.ServeHTTP(w, r)
+					if rec, ok := w.(*recorderWriter); ok && (rec.status != 200 || rec.size != len(route.path)) {
+						t.Errorf("%s: %d - %d bytes; expected %s %s", mode.name, rec.status, rec.size, route.method, route.path)
+					}
+					w.finish()
+					if out.Len() == 0 {
+						continue
+					}
+					resp, body := readResponse(t, &out)
+					if resp.StatusCode != 200 || body != route.path {
+						t.Errorf("%s: %d - %s; expected %s %s", mode.name, resp.StatusCode, body, route.method, route.path)
+					}
+				}
+			}
+		})
+	}
+}
+
+// The writer mode variants of the benchmarks, added with -writers
+func BenchmarkWriters(b *testing.B) {
+	for _, name := range writerBenchmarks {
+		b.Run(name, func(b *testing.B) {
+			runBenchmark(b, name)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2014 Julien Schmidt. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package main
+
+// import (
+// 	"io"
+// 	"net/http"
+
+// 	"github.com/daryl/zeus"
+// )
+
+// func init() {
+// 	register(zeusAdapter{})
+// }
+
+// type zeusAdapter struct{}
+
+// func (zeusAdapter) Name() string      { return "Zeus" }
+// func (zeusAdapter) Features() Feature { return FeatureParams }
+
+// func (zeusAdapter) Load(routes []route) http.Handler {
+// 	return loadZeus(routes)
+// }
+
+// func (zeusAdapter) LoadSingle(method, path string, write bool) http.Handler {
+// 	h := http.HandlerFunc(httpHandlerFunc)
+// 	if write {
+// 		h = zeusHandlerWrite
+// 	}
+// 	return loadZeusSingle(method, path, h)
+// }
+
+// func zeusHandlerWrite(w http.ResponseWriter, r *http.Request) {
+// 	io.WriteString(w, zeus.Var(r, "name"))
+// }
+
+// func loadZeus(routes []route) http.Handler {
+// 	h := http.HandlerFunc(httpHandlerFunc)
+// 	if loadTestHandler {
+// 		h = http.HandlerFunc(httpHandlerFuncTest)
+// 	}
+
+// 	m := zeus.New()
+// 	for _, route := range routes {
+// 		switch route.method {
+// 		case http.MethodGet:
+// 			m.GET(route.path, h)
+// 		case http.MethodPost:
+// 			m.POST(route.path, h)
+// 		case http.MethodPut:
+// 			m.PUT(route.path, h)
+// 		case http.MethodDelete:
+// 			m.DELETE(route.path, h)
+// 		default:
+// 			panic("Unknow HTTP method: " + route.method)
+// 		}
+// 	}
+// 	return m
+// }
+
+// func loadZeusSingle(method, path string, handler http.HandlerFunc) http.Handler {
+// 	m := zeus.New()
+// 	switch method {
+// 	case http.MethodGet:
+// 		m.GET(path, handler)
+// 	case http.MethodPost:
+// 		m.POST(path, handler)
+// 	case http.MethodPut:
+// 		m.PUT(path, handler)
+// 	case http.MethodDelete:
+// 		m.DELETE(path, handler)
+// 	default:
+// 		panic("Unknow HTTP method: " + method)
+// 	}
+// 	return m
+// }
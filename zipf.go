@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// trafficSpec describes the request stream of the Zipf benchmarks, see
+// zipfRequests.
+type trafficSpec struct {
+	// exponent is the s of the Zipf distribution, which has to be greater
+	// than 1. The larger it is, the more requests go to the hottest routes.
+	// 0 draws the routes uniformly.
+	exponent float64
+
+	// seed seeds the random numbers, the same seed results in the same
+	// stream
+	seed uint64
+
+	// requests is the length of the stream
+	requests int
+}
+
+// zipfTraffic is the request stream of the Zipf benchmarks. About 70% of
+// the requests of the GitHub API go to 10% of its routes.
+var zipfTraffic = trafficSpec{
+	exponent: 1.1,
+	seed:     1,
+	requests: 10000,
+}
+
+// validate returns an error if the spec doesn't describe a stream.
+func (t trafficSpec) validate() error {
+	if t.exponent != 0 && t.exponent <= 1 {
+		return fmt.Errorf("invalid Zipf exponent %g: has to be greater than 1, or 0 for uniform traffic", t.exponent)
+	}
+	if t.requests < 1 {
+		return fmt.Errorf("invalid number of requests %d", t.requests)
+	}
+	return nil
+}
+
+// zipfRequests returns a stream of requests for the routes. The routes are
+// ranked in a random order and drawn by the Zipf distribution over the ranks,
+// so the first routes of an API aren't the hottest. Each parameter gets one
+// of 1000 values, a catch-all parameter wildcardValue.
+func zipfRequests(routes []route, spec trafficSpec) []route {
+	rng := rand.New(rand.NewPCG(spec.seed, spec.seed))
+	ranked := make([]route, len(routes))
+	for i, j := range rng.Perm(len(routes)) {
+		ranked[i] = routes[j]
+	}
+
+	draw := func() uint64 { return rng.Uint64N(uint64(len(ranked))) }
+	if spec.exponent != 0 {
+		draw = rand.NewZipf(rng, spec.exponent, 1, uint64(len(ranked)-1)).Uint64
+	}
+
+	reqs := make([]route, spec.requests)
+	for i := range reqs {
+		r := ranked[draw()]
+		segments := strings.Split(r.path, "/")
+		for j, segment := range segments {
+			switch {
+			case strings.HasPrefix(segment, ":"):
+				segments[j] = segment[1:] + strconv.Itoa(rng.IntN(1000))
+			case strings.HasPrefix(segment, "*"):
+				segments[j] = wildcardValue
+			}
+		}
+		reqs[i] = route{r.method, strings.Join(segments, "/")}
+	}
+	return reqs
+}
+
+// zipfBenchmark routes the requests of the zipfTraffic stream for the API, one
+// per operation. With latencyMode set it reports the percentiles of the
+// request latency like the replay benchmarks.
+func zipfBenchmark(name, apiName string) benchmark {
+	return benchmark{
+		name: name,
+		api:  apiName,
+		supports: func(a Adapter) bool {
+			return canServe(a, findAPI(apiName).routes)
+		},
+		run: func(b *testing.B, a Adapter) {
+			api := findAPI(apiName)
+			benchStream(b, apiHandler(api, a), zipfRequests(api.routes, zipfTraffic))
+		},
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestZipfRequests(t *testing.T) {
+	routes := findAPI("GitHub").routes
+	spec := trafficSpec{exponent: 1.1, seed: 1, requests: 10000}
+
+	reqs := zipfRequests(routes, spec)
+	if len(reqs) != spec.requests {
+		t.Fatalf("got %d requests, expected %d", len(reqs), spec.requests)
+	}
+	if !slices.Equal(reqs, zipfRequests(routes, spec)) {
+		t.Error("the same seed resulted in another stream")
+	}
+	spec.seed = 2
+	if slices.Equal(reqs, zipfRequests(routes, spec)) {
+		t.Error("another seed resulted in the same stream")
+	}
+
+	// The hottest tenth of the routes gets most of the requests, unless
+	// they are drawn uniformly
+	hot := func(reqs []route) float64 {
+		var counts []int
+		for _, r := range routes {
+			counts = append(counts, len(replayRequests([]route{r}, reqs)))
+		}
+		slices.Sort(counts)
+		n := 0
+		for _, c := range counts[len(counts)-len(counts)/10:] {
+			n += c
+		}
+		return float64(n) / float64(len(reqs))
+	}
+	if share := hot(reqs); share < 0.6 {
+		t.Errorf("the hottest tenth of the routes got %.0f%% of the requests, expected more than 60%%", share*100)
+	}
+	spec.exponent = 0
+	if share := hot(zipfRequests(routes, spec)); share > 0.2 {
+		t.Errorf("the hottest tenth of the routes got %.0f%% of the uniform requests, expected less than 20%%", share*100)
+	}
+}
+
+func TestTrafficSpecValidate(t *testing.T) {
+	for _, spec := range []trafficSpec{{1.1, 1, 1}, {0, 0, 100}, {2, 3, 10}} {
+		if err := spec.validate(); err != nil {
+			t.Errorf("%+v: %v", spec, err)
+		}
+	}
+	for _, spec := range []trafficSpec{{1, 1, 1}, {0.5, 1, 1}, {-1, 1, 1}, {1.1, 1, 0}} {
+		if err := spec.validate(); err == nil {
+			t.Errorf("%+v: expected an error", spec)
+		}
+	}
+}
+
+// TestRoutersZipf checks that the routers route the requests of the Zipf
+// benchmarks, with their parameter values.
+func TestRoutersZipf(t *testing.T) {
+	loadTestHandler = true
+	defer func() { loadTestHandler = false }()
+
+	for _, router := range adapters {
+		for _, api := range apis {
+			if !canServe(router, api.routes) {
+				continue
+			}
+			h := router.Load(api.routes)
+			for _, route := range zipfRequests(api.routes, trafficSpec{exponent: 1.1, seed: 1, requests: 200}) {
+				req, _ := http.NewRequest(route.method, route.path, nil)
+				req.RequestURI = route.path
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, req)
+				if w.Code != 200 || w.Body.String() != route.path {
+					t.Errorf("%s in API %s: %d - %s; expected %s %s",
+						router.Name(), api.name, w.Code, w.Body.String(), route.method, route.path)
+				}
+			}
+		}
+	}
+}